@@ -1,23 +1,63 @@
 package command
 
 import (
+	// go
+	"errors"
+	"net/http"
+	"os"
+	"strings"
 
 	// lib
-	. "github.com/Kenshin/cprint"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	// local
 	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
 	"gnvm/nodehandle"
 	"gnvm/util"
 )
 
 var (
-	global bool
-	remote bool
-	detail bool
-	io     bool
-	limit  int
+	global  bool
+	remote  bool
+	detail  bool
+	io      bool
+	limit   int
+	long    bool
+	columns string
+	channel string
+	from    string
+	as      string
+	mode    string
+	prune   bool
+	dryRun  bool
+	verify  bool
+	repair  bool
+	keep    int
+	major   int
+	older   string
+	unused  string
+	migrate bool
+	jsonFmt bool
+	format  string
+	explain bool
+
+	packFrom   string
+	packLatest bool
+
+	root      string
+	registry  string
+	overrides []string
+
+	quiet   bool
+	verbose bool
+	debug   bool
+	noColor bool
+
+	yes bool
+	no  bool
 )
 
 // defind root cmd
@@ -27,7 +67,61 @@ var gnvmCmd = &cobra.Command{
 	Long: `GNVM is simple Node.js version manager on Windows by GO. e.g. nvm, nvmw, nodist.
 Copyright (C) 2014-2016 Kenshin Wang <kenshin@ksria.com>
 See https://github.com/kenshin/gnvm for more information.
+
+Exit code:
+  0 :success.
+  1 :unknown error.
+  2 :usage error, e.g. invalid parameter or flag.
+  3 :network error, e.g. registry unreachable or download fail.
+  4 :Node.js version or npm not installed.
+  5 :checksum mismatch or download size error.
+  6 :.gnvmrc read or write error.
+  7 :command not support in session environment.
+  8 :operation has been cancelled.
 `,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setLevel(); err != nil {
+			return err
+		}
+		if err := setAnswer(); err != nil {
+			return err
+		}
+		if root != "" {
+			if err := util.SetRoot(root); err != nil {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v value %v, Error: %v. See '%v'.\n", "--root", root, err.Error(), "gnvm help")
+			}
+		} else if err := util.VerifyRoot(); err != nil {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v value %v, Error: %v. See '%v'.\n", util.GNVM_HOME, os.Getenv(util.GNVM_HOME), err.Error(), "gnvm help")
+		}
+		if jsonFmt {
+			if cmd.Flags().Changed("format") && strings.ToLower(strings.TrimSpace(format)) != util.FORMAT_JSON {
+				return util.Fail(util.EXIT_USAGE, ERROR, "flag %v can not be used with %v. See '%v'.\n", "--json", "--format="+format, "gnvm help")
+			}
+			format = util.FORMAT_JSON
+		}
+		if err := util.SetFormat(format); err != nil {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help")
+		}
+		if err := config.Init(); err != nil {
+			return err
+		}
+		if registry != "" {
+			overrides = append(overrides, config.REGISTRY+"="+registry)
+		}
+		for _, v := range overrides {
+			arr := strings.SplitN(v, "=", 2)
+			if len(arr) != 2 {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v format error, must be '%v'. See '%v'.\n", v, "--config <key>=<value>", "gnvm help config")
+			}
+			if err := config.Override(configKey(arr[0]), arr[1]); err != nil {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help config")
+			}
+		}
+		SetLang(config.GetConfig(config.LANG))
+		return nodehandle.Init()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// TO DO
 	},
@@ -42,11 +136,11 @@ gnvm version           :Print local  gnvm version information.
 gnvm version -r        :Print remote gnvm latest version.
 gnvm version -r -d     :Print remote CHANGELOG.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			P(WARING, "'%v' no parameter, please check your input. See '%v'.\n", "gnvm version", "gnvm help version")
 		}
-		nodehandle.Version(remote, detail)
+		return nodehandle.Version(remote, detail)
 	},
 }
 
@@ -60,25 +154,47 @@ gnvm install x.xx.xx y.yy.yy         :Multiple Node.js version download.
 gnvm install x.xx.xx-x86             :Assign arch  version, suffix include: x86 and x64.
 gnvm install 1.xx.xx                 :Assign io.js version.
 gnvm install x.xx.xx --global        :Download and auto invoke 'gnvm use x.xx.xx'.
+gnvm install nightly                 :Download latest nightly version, channel include: rc, nightly, test and custom channel.
+gnvm install rc/xx.x.x-rc.x          :Download rc channel version to rc@xx.x.x-rc.x folder.
+gnvm install --from node-vx.xx.xx-win-x64.zip     :Install local Node.js archive to x.xx.xx folder, include: .zip and node.exe.
+gnvm install --from <url> --as x.xx.xx-<tag>      :Download custom Node.js build and install to x.xx.xx-<tag> folder.
+gnvm install x.xx.xx --reinstall-packages-from=global   :Download and reinstall global npm packages of global version.
 gnvm install npm                     :Not logger support command, please usage 'gnvm npm x.xx.xx'. See 'gnvm help npm'.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 0 {
-			P(ERROR, "'%v' need parameter, please check your input. See '%v'.\n", "gnvm install", "gnvm help install")
-		} else {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if as != "" && from == "" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "flag %v depends on %v flag, e.g. '%v', See '%v'.\n", "--as", "--from", "gnvm install --from <url> --as x.xx.xx-<tag>", "gnvm help install")
+		}
+		if from != "" && len(args) > 0 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "flag %v can not be used with %v. See '%v'.\n", "--from", "<version>", "gnvm help install")
+		}
+		if len(args) == 0 && from == "" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "'%v' need parameter, please check your input. See '%v'.\n", "gnvm install", "gnvm help install")
+		}
+		if packFrom != "" && (from != "" || len(args) != 1) {
+			return util.Fail(util.EXIT_USAGE, ERROR, "flag %v must be used with only one %v, e.g. '%v'. See '%v'.\n", "--reinstall-packages-from", "<version>", "gnvm install x.xx.xx --reinstall-packages-from=y.yy.yy", "gnvm help install")
+		}
+		if packLatest && packFrom == "" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "flag %v depends on %v flag, e.g. '%v', See '%v'.\n", "--latest-packages", "--reinstall-packages-from", "gnvm install x.xx.xx --reinstall-packages-from=y.yy.yy --latest-packages", "gnvm help install")
+		}
 
-			if global {
-				if _, ok := util.IsSessionEnv("install -g", true); ok {
-					return
-				}
+		if global {
+			if err := sessionEnv("install -g"); err != nil {
+				return err
 			}
+		}
 
-			if global && len(args) > 1 {
-				P(WARING, "when use %v must be only one parameter, e.g. '%v'. See '%v'.\n", "-g", "gnvm install x.xx.xx -g", "gnvm install help")
-			}
+		if global && len(args) > 1 {
+			P(WARING, "when use %v must be only one parameter, e.g. '%v'. See '%v'.\n", "-g", "gnvm install x.xx.xx -g", "gnvm install help")
+		}
 
-			nodehandle.InstallNode(args, global)
+		if from != "" {
+			return nodehandle.InstallFrom(from, as, global)
 		}
+		if packFrom != "" {
+			return nodehandle.InstallWithGlobals(args[0], packFrom, global, packLatest)
+		}
+		return nodehandle.InstallNode(args, global)
 	},
 }
 
@@ -91,21 +207,20 @@ gnvm uninstall npm                         :Uninstall npm.
 gnvm uninstall 0.10.28                     :Uninstall 0.10.28  Node.js version.
 gnvm uninstall latest                      :Uninstall latest   Node.js version.
 gnvm uninstall 0.10.26 0.11.2-x86 latest   :Uninstall multiple Node.js version, e.g. 0.10.26 0.11.2-x86 latest.
+gnvm uninstall rc/xx.x.x-rc.x              :Uninstall rc channel Node.js version, or usage rc@xx.x.x-rc.x.
 gnvm uninstall ALL                         :Uninstall all      Node.js version.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if _, ok := util.IsSessionEnv("uninstall", true); ok {
-			return
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if err := sessionEnv("uninstall"); err != nil {
+			return err
 		}
 		if len(args) == 0 {
-			P(ERROR, "%v need parameter, please check your input. See '%v'.\n", "gnvm uninstall", "gnvm help uninstall")
-			return
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v need parameter, please check your input. See '%v'.\n", "gnvm uninstall", "gnvm help uninstall")
 		} else if len(args) == 1 {
 			args[0] = util.EqualAbs("ALL", args[0])
 			if args[0] == "ALL" {
-				if newArr, err := nodehandle.LS(false); err != nil {
-					P(ERROR, "remove all folder Error: %v\n", err.Error())
-					return
+				if newArr, err := nodehandle.LS(false, false); err != nil {
+					return util.Fail(util.EXIT_ERROR, ERROR, "remove all folder Error: %v\n", err.Error())
 				} else {
 					args = newArr
 				}
@@ -116,28 +231,36 @@ gnvm uninstall ALL                         :Uninstall all      Node.js version.
 
 			v = util.EqualAbs("npm", v)
 			if v == "npm" {
-				nodehandle.UninstallNPM()
+				if e := nodehandle.UninstallNPM(); e != nil {
+					err = e
+				}
 				continue
 			}
 
 			v = util.EqualAbs("ALL", v)
 			if v == "ALL" {
-				P(WARING, "'%v' not supported mixed parameters, please usage '%v'. See '%v'.\n", "gnvm uninstall ALL", "gnvm uninstall ALL", "gnvm help uninstall")
+				err = util.Fail(util.EXIT_USAGE, WARING, "'%v' not supported mixed parameters, please usage '%v'. See '%v'.\n", "gnvm uninstall ALL", "gnvm uninstall ALL", "gnvm help uninstall")
 				continue
 			}
 
+			// check version format
+			if _, _, _, _, e := util.ParseNodeVer(v); e != nil && !util.IsBuild(util.GlobalNodePath, v) {
+				P(ERROR, "%v. See '%v'.\n", e.Error(), "gnvm help uninstall")
+				err = e
+				continue
+			}
+			v = util.ChannelFolder(v)
+
 			v = util.EqualAbs("latest", v)
 			if v == util.LATEST {
 				util.FormatLatVer(&v, config.GetConfig(config.LATEST_VERSION), true)
 			}
 
-			// check version format
-			if !util.VerifyNodeVer(v) {
-				P(ERROR, "%v not an %v Node.js version.\n", v, "valid")
-			} else {
-				nodehandle.Uninstall(v)
+			if e := nodehandle.Uninstall(v); e != nil {
+				err = e
 			}
 		}
+		return err
 	},
 }
 
@@ -149,27 +272,32 @@ var useCmd = &cobra.Command{
 gnvm use x.xx.xx      :Usage x.xx.xx Node.js version.
 gnvm use latest       :Usage latest  Node.js version.
 gnvm use x.xx.xx-x86  :Usage x.xx.xx Node.js with arch x86 version.
+gnvm use rc/xx.x.x-rc.x :Usage rc channel Node.js version, or usage rc@xx.x.x-rc.x.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if _, ok := util.IsSessionEnv("use", true); ok {
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := sessionEnv("use"); err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm use", "one", "gnvm help use")
 		}
-		if len(args) == 1 {
-			version := args[0]
-			version = util.EqualAbs("latest", version)
-			if util.VerifyNodeVer(version) != true {
-				P(ERROR, "%v param only support [%v] or %v e.g. [%v], please check your input. See '%v'.\n", "gnvm use", "latest", "valid Node.js version", "5.9.1", "gnvm help use")
-				return
-			}
 
-			// set use
-			if ok := nodehandle.Use(version); ok == true {
-				util.FormatLatVer(&version, config.GetConfig(config.LATEST_VERSION), false)
-				config.SetConfig(config.GLOBAL_VERSION, version)
-			}
-		} else {
-			P(ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm use", "one", "gnvm help use")
+		version := util.ChannelFolder(args[0])
+		version = util.EqualAbs("latest", version)
+		if _, _, _, _, err := util.ParseNodeVer(version); err != nil && !util.IsBuild(util.GlobalNodePath, version) {
+			P(ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help use")
+			return err
 		}
+
+		// set use
+		if err := nodehandle.Use(version); err != nil {
+			return err
+		}
+		util.FormatLatVer(&version, config.GetConfig(config.LATEST_VERSION), false)
+		if config.SetConfig(config.GLOBAL_VERSION, version) == "" {
+			return util.Errorf(util.EXIT_CONFIG, "set %v fail", config.GLOBAL_VERSION)
+		}
+		return nil
 	},
 }
 
@@ -188,21 +316,19 @@ gns run 0.10.24           :Set 0.10.24 is session environment.
 gns clear                 :Quit sesion Node.js, restore global Node.js version.
 gns version               :Show gns version.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 1 {
-			args[0] = util.EqualAbs("start", args[0])
-			args[0] = util.EqualAbs("close", args[0])
-			if args[0] != "start" && args[0] != "close" {
-				P(ERROR, "%v only support [%v] or [%v] parameter. See '%v'.\n", "gnvm session", "start", "close", "gnvm help session")
-			} else {
-				if _, ok := util.IsSessionEnv("session "+args[0], true); ok {
-					return
-				}
-				nodehandle.Run(args[0])
-			}
-		} else {
-			P(ERROR, "%v need parameter and only one parameter, support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm session", "start", "close", "gnvm help session")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v need parameter and only one parameter, support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm session", "start", "close", "gnvm help session")
+		}
+		args[0] = util.EqualAbs("start", args[0])
+		args[0] = util.EqualAbs("close", args[0])
+		if args[0] != "start" && args[0] != "close" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v only support [%v] or [%v] parameter. See '%v'.\n", "gnvm session", "start", "close", "gnvm help session")
 		}
+		if err := sessionEnv("session " + args[0]); err != nil {
+			return err
+		}
+		return nodehandle.Run(args[0])
 	},
 }
 
@@ -214,22 +340,20 @@ var updateCmd = &cobra.Command{
     gnvm update latest       :Download latest Node.js and write it(latest version) to .gnvmrc.
     gnvm update latest -g    :Download latest Node.js and write it(latest version) to .gnvmrc and auto invoke 'gnvm use latest'.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 1 {
-			if global {
-				if _, ok := util.IsSessionEnv("update -g", true); ok {
-					return
-				}
-			}
-			args[0] = util.EqualAbs("latest", args[0])
-			if args[0] == util.LATEST {
-				nodehandle.Update(global)
-			} else {
-				P(ERROR, "%v only support [%v] keyword, please check your input. See '%v'.\n", "gnvm update", "latest", "gnvm help update")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one parameter and only support [%v] keyword, please check your input. See '%v'.\n", "gnvm update", "latest", "gnvm help update")
+		}
+		if global {
+			if err := sessionEnv("update -g"); err != nil {
+				return err
 			}
-		} else {
-			P(ERROR, "%v must be one parameter and only support [%v] keyword, please check your input. See '%v'.\n", "gnvm update", "latest", "gnvm help update")
 		}
+		args[0] = util.EqualAbs("latest", args[0])
+		if args[0] != util.LATEST {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v only support [%v] keyword, please check your input. See '%v'.\n", "gnvm update", "latest", "gnvm help update")
+		}
+		return nodehandle.Update(global)
 	},
 }
 
@@ -244,35 +368,51 @@ gnvm ls -r -d            :Print remote Node.js details version list.
 gnvm ls -r -i            :Print remote io.js   version list.
 gnvm ls -r -d -i         :Print remote io.js   details version list.
 gnvm ls -r -d --limit=xx :Print remote Node.js maximum number of rows is xx.( default, print max rows. )
+gnvm ls --json           :Print local  Node.js version list as json, or usage --format=tsv.
+gnvm ls -L               :Print local  Node.js version list with arch, size, install date, registry, npm, lts, last used and total disk usage.
+gnvm ls -r -d --json     :Print remote Node.js details version list as json, include: version, date, arch, npm, lts, v8, openssl, modules, files and so on.
+gnvm ls -r -d --columns=version,openssl,modules :Print remote Node.js details version list only include columns, or usage --columns=all.
+gnvm ls -r --channel=nightly :Print remote nightly Node.js version list, channel include: release, rc, nightly, test and custom channel.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
-			P(WARING, "%v no parameter, please check your input. See '%v'.\n", "gnvm ls", "gnvm help ls")
-		} else {
-			switch {
-			case !remote && !detail:
-				if io {
-					P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "-i", "gnvm help ls")
-				}
-				if limit != 0 {
-					P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "-l", "gnvm help ls")
-				}
-				nodehandle.LS(true)
-			case remote && !detail:
-				if limit != 0 {
-					P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls -r", "-l", "gnvm help ls")
-				}
-				nodehandle.LsRemote(-1, io)
-			case remote && detail:
-				if limit < 0 {
-					P(WARING, "%v must be positive integer, please check your input. See '%v'.\n", "--limit", "gnvm help ls")
-				} else {
-					nodehandle.LsRemote(limit, io)
-				}
-			case !remote && detail:
-				P(ERROR, "flag %v depends on %v flag, e.g. '%v', See '%v'.", "-d", "-r", "gnvm ls -r -d", "gnvm help ls", "\n")
+			return util.Fail(util.EXIT_USAGE, WARING, "%v no parameter, please check your input. See '%v'.\n", "gnvm ls", "gnvm help ls")
+		}
+		if columns != "" {
+			if !remote || !detail {
+				P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "--columns", "gnvm help ls")
+			} else if err := nodehandle.SetColumns(columns); err != nil {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help ls")
+			}
+		}
+		if long && remote {
+			P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls -r", "-L", "gnvm help ls")
+		}
+		if channel != "" && !remote {
+			P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "--channel", "gnvm help ls")
+		}
+		switch {
+		case !remote && !detail:
+			if io {
+				P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "-i", "gnvm help ls")
 			}
+			if limit != 0 {
+				P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls", "--limit", "gnvm help ls")
+			}
+			_, err := nodehandle.LS(true, long)
+			return err
+		case remote && !detail:
+			if limit != 0 {
+				P(WARING, "%v no support flag %v, please check your input. See '%v'.\n", "gnvm ls -r", "--limit", "gnvm help ls")
+			}
+			return nodehandle.LsRemote(-1, io, channel)
+		case remote && detail:
+			if limit < 0 {
+				return util.Fail(util.EXIT_USAGE, WARING, "%v must be positive integer, please check your input. See '%v'.\n", "--limit", "gnvm help ls")
+			}
+			return nodehandle.LsRemote(limit, io, channel)
 		}
+		return util.Fail(util.EXIT_USAGE, ERROR, "flag %v depends on %v flag, e.g. '%v', See '%v'.\n", "-d", "-r", "gnvm ls -r -d", "gnvm help ls")
 	},
 }
 
@@ -285,20 +425,18 @@ gnvm node-version            :Show Node.js global and latest version, and fix it
 gnvm node-version latest     :Show Node.js latest version, and fix it.
 gnvm node-version global     :Show Node.js global version, and fix it.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
-			P(WARING, "%v parameter only support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm node-version", "global", "latest", "gnvm help node-version")
-		} else {
-			if len(args) == 1 {
-				args[0] = util.EqualAbs("global", args[0])
-				args[0] = util.EqualAbs("latest", args[0])
-				if args[0] != "global" && args[0] != "latest" {
-					P(WARING, "%v parameter only support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm node-version", "global", "latest", "gnvm help node-version")
-					return
-				}
+			return util.Fail(util.EXIT_USAGE, WARING, "%v parameter only support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm node-version", "global", "latest", "gnvm help node-version")
+		}
+		if len(args) == 1 {
+			args[0] = util.EqualAbs("global", args[0])
+			args[0] = util.EqualAbs("latest", args[0])
+			if args[0] != "global" && args[0] != "latest" {
+				return util.Fail(util.EXIT_USAGE, WARING, "%v parameter only support [%v] or [%v] keyword, please check your input. See '%v'.\n", "gnvm node-version", "global", "latest", "gnvm help node-version")
 			}
-			nodehandle.NodeVersion(args)
 		}
+		return nodehandle.NodeVersion(args)
 	},
 }
 
@@ -308,68 +446,187 @@ var configCmd = &cobra.Command{
 	Short: "Setter and getter .gnvmrc file",
 	Long: `Setter and getter .gnvmrc file.  e.g. :
 gnvm config                   :Print all propertys from .gnvmrc.
+gnvm config --json            :Print all propertys from .gnvmrc as json, or usage --format=tsv.
+gnvm config --explain         :Print all propertys and where each effective value came from.
 gnvm config INIT              :Initialization .gnvmrc file.
 gnvm config [props]           :Get .gnvmrc file props.
+gnvm config set [props] [val] :Set .gnvmrc file props, value must be valid url, path, version, bool or duration.
+gnvm config unset [props]     :Remove .gnvmrc file props, restore default value.
 gnvm config registry [custom] :Custom  is valid url.
 gnvm config registry DEFAULT  :DEFAULT is built-in variable. value is https://nodejs.org/dist/
 gnvm config registry TAOBAO   :TAOBAO  is built-in variable. value is https://cdn.npmmirror.com/binaries/node/
 gnvm config registry HUAWEI   :HUAWEI  is built-in variable. value is https://mirrors.huaweicloud.com/nodejs/
 gnvm config registry test     :Validation .gnvmfile registry property.
+gnvm config proxy [custom]    :Custom  is valid http proxy url.
+gnvm config timeout [custom]  :Custom  is valid duration, e.g. 10s 1m.
+gnvm config lang [custom]     :Custom  is message language, include: en and zh-CN.
+gnvm config schedule [custom] :Custom  is valid url, schedule.json folder, e.g. https://raw.githubusercontent.com/nodejs/Release/main/
+gnvm config silence [custom]  :Custom  is comma separated Node.js versions, not warn end-of-life and security release.
+gnvm config channel.[name] [custom] :Custom is channel download url, e.g. gnvm config channel.beta https://example.com/beta/
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			args[0] = util.EqualAbs("set", args[0])
+			args[0] = util.EqualAbs("unset", args[0])
+		}
+		if len(args) > 0 && args[0] == "set" {
+			if len(args) != 3 {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v must be two parameter, e.g. '%v'. See '%v'.\n", "gnvm config set", "gnvm config set registry TAOBAO", "gnvm help config")
+			}
+			args = args[1:]
+		} else if len(args) > 0 && args[0] == "unset" {
+			if len(args) != 2 {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one parameter, e.g. '%v'. See '%v'.\n", "gnvm config unset", "gnvm config unset proxy", "gnvm help config")
+			}
+			key := configKey(args[1])
+			value, err := config.UnsetConfig(key)
+			if err != nil {
+				return util.Fail(util.EXIT_CONFIG, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help config")
+			}
+			P(DEFAULT, "Unset success, %v restore default value %v\n", key, value)
+			return nil
+		}
+
 		if len(args) == 0 {
-			config.List()
+			if explain {
+				config.ExplainAll()
+			} else if !util.IsText() {
+				config.JSON()
+			} else {
+				config.List()
+			}
 		} else if len(args) == 1 {
 			args[0] = util.EqualAbs("INIT", args[0])
-			args[0] = util.EqualAbs("registry", args[0])
-			args[0] = util.EqualAbs("noderoot", args[0])
-			args[0] = util.EqualAbs("latestversion", args[0])
-			args[0] = util.EqualAbs("globalversion", args[0])
 			if args[0] == "INIT" {
 				config.ReSetConfig()
 			} else {
-				value := config.GetConfig(args[0])
-				if value == util.UNKNOWN {
-					P(ERROR, "%v not a valid config keyword. See '%v'.\n", args[0], "gnvm help config")
-				} else {
-					P(DEFAULT, "gnvm config %v is %v\n", args[0], value)
+				key := configKey(args[0])
+				if _, err := config.Lookup(key); err != nil {
+					return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help config")
 				}
+				P(DEFAULT, "gnvm config %v is %v\n", key, config.GetConfig(key))
 			}
 		} else if len(args) == 2 {
-			args[0] = util.EqualAbs("registry", args[0])
-			args[1] = util.EqualAbs("DEFAULT", args[1])
-			args[1] = util.EqualAbs("TAOBAO", args[1])
-			args[1] = util.EqualAbs("test", args[1])
-			if args[0] != "registry" {
-				P(ERROR, "%v only support [%v] keyword. See '%v'.\n", "gnvm config", "registry", "gnvm help config")
-				return
+			key := configKey(args[0])
+			if _, err := config.Lookup(key); err != nil {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help config")
 			}
-			switch args[1] {
-			case "DEFAULT":
-				if newValue := config.SetConfig(args[0], util.ORIGIN_DEFAULT); newValue != "" {
-					P(DEFAULT, "Set success, %v new value is %v\n", args[0], newValue)
-				}
-			case "TAOBAO":
-				if newValue := config.SetConfig(args[0], util.ORIGIN_TAOBAO); newValue != "" {
-					P(DEFAULT, "Set success, %v new value is %v\n", args[0], newValue)
-				}
-			case "HUAWEI":
-				if newValue := config.SetConfig(args[0], util.ORIGIN_HUAWEI); newValue != "" {
-					P(DEFAULT, "Set success, %v new value is %v\n", args[0], newValue)
-				}
-			case "test":
-				config.Verify()
-			default:
-				if newValue := config.SetConfig(args[0], args[1]); newValue != "" {
-					P(DEFAULT, "Set success, %v new value is %v\n", args[0], newValue)
+			value := args[1]
+			if key == config.REGISTRY {
+				value = util.EqualAbs("DEFAULT", value)
+				value = util.EqualAbs("TAOBAO", value)
+				value = util.EqualAbs("HUAWEI", value)
+				value = util.EqualAbs("test", value)
+				switch value {
+				case "DEFAULT":
+					value = util.ORIGIN_DEFAULT
+				case "TAOBAO":
+					value = util.ORIGIN_TAOBAO
+				case "HUAWEI":
+					value = util.ORIGIN_HUAWEI
+				case "test":
+					return config.Verify()
 				}
 			}
+			newValue := config.SetConfig(key, value)
+			if newValue == "" {
+				return util.Errorf(util.EXIT_CONFIG, "set %v fail", key)
+			}
+			P(DEFAULT, "Set success, %v new value is %v\n", key, newValue)
 		} else {
-			P(ERROR, "%v parameter maximum is 2, please check your input. See '%v'.\n", "gnvm config", "gnvm help config")
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v parameter maximum is 2, please check your input. See '%v'.\n", "gnvm config", "gnvm help config")
 		}
+		return nil
 	},
 }
 
+/*
+Return *util.ExitError when current is session environment
+
+Param:
+  - cmd: gnvm sub command, e.g. "install -g"
+*/
+func sessionEnv(cmd string) error {
+	if _, ok := util.IsSessionEnv(cmd, true); ok {
+		return util.Errorf(util.EXIT_SESSION, "'gnvm %v' not support in session environment", cmd)
+	}
+	return nil
+}
+
+/*
+Set console level and color by --quiet --verbose --debug and --no-color, --debug print http requests
+*/
+func setLevel() error {
+	level := LEVEL_NORMAL
+	switch {
+	case quiet && (verbose || debug):
+		return util.Fail(util.EXIT_USAGE, ERROR, "flag %v can not be used with %v or %v. See '%v'.\n", "--quiet", "--verbose", "--debug", "gnvm help")
+	case quiet:
+		level = LEVEL_QUIET
+	case debug:
+		level = LEVEL_DEBUG
+		util.HTTPClient = &http.Client{Transport: &util.DebugTransport{Transport: http.DefaultTransport}}
+	case verbose:
+		level = LEVEL_VERBOSE
+	}
+	Configure(level, noColor)
+	return nil
+}
+
+/*
+Set prompt answer by --yes and --no
+*/
+func setAnswer() error {
+	switch {
+	case yes && no:
+		return util.Fail(util.EXIT_USAGE, ERROR, "flag %v can not be used with %v. See '%v'.\n", "--yes", "--no", "gnvm help")
+	case yes:
+		util.Answer = util.ANSWER_YES
+	case no:
+		util.Answer = util.ANSWER_NO
+	}
+	return nil
+}
+
+/*
+Translate cobra help text by current language, include Short, Long, flag usage and usage template
+
+Param:
+  - cmd: *cobra.Command, translate all sub commands
+*/
+func translate(cmd *cobra.Command) {
+	if Lang == LANG_EN {
+		return
+	}
+	cmd.Short = T(cmd.Short)
+	cmd.Long = TLines(cmd.Long)
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Name == "help" {
+			flag.Usage = strings.Replace(T("help for %v"), "%v", cmd.Name(), 1)
+		} else {
+			flag.Usage = T(flag.Usage)
+		}
+	})
+	tmpl := cmd.UsageTemplate()
+	for _, s := range []string{"Usage:", "Aliases:", "Examples:", "Available Commands:", "Additional Commands:", "Global Flags:", "Flags:", "Additional help topics:", `Use "{{.CommandPath}} [command] --help" for more information about a command.`} {
+		tmpl = strings.Replace(tmpl, s, T(s), -1)
+	}
+	cmd.SetUsageTemplate(tmpl)
+	for _, sub := range cmd.Commands() {
+		translate(sub)
+	}
+}
+
+/*
+Ignore config property name case, e.g. REGISTRY to registry
+*/
+func configKey(key string) string {
+	for _, k := range config.Keys {
+		key = util.EqualAbs(k.Name, key)
+	}
+	return key
+}
+
 // sub cmd
 var regCmd = &cobra.Command{
 	Use:   "reg",
@@ -378,17 +635,15 @@ var regCmd = &cobra.Command{
 Add config property [noderoot] to Environment variable [NODE_HOME]. e.g. :
 gnvm reg noderoot   :Registry config noderoot to NODE_HOME and add to Path.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 || len(args) == 0 {
-			P(ERROR, "%v must be one parameter and only support [%v] keyword, please check your input. See '%v'.\n", "gnvm reg", "noderoot", "gnvm help reg")
-			return
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one parameter and only support [%v] keyword, please check your input. See '%v'.\n", "gnvm reg", "noderoot", "gnvm help reg")
 		}
 		noderoot := util.EqualAbs("noderoot", args[0])
 		if noderoot != "noderoot" {
-			P(ERROR, "%v only support [%v] keyword, please check your input. See '%v'.\n", "gnvm reg", "noderoot", "gnvm help reg")
-			return
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v only support [%v] keyword, please check your input. See '%v'.\n", "gnvm reg", "noderoot", "gnvm help reg")
 		}
-		nodehandle.Reg(noderoot)
+		return nodehandle.Reg(noderoot)
 	},
 }
 
@@ -403,13 +658,18 @@ gnvm search 0.10.*         :Search and Print 0.10.0 ~ 0.10.99 range Node.js vers
 gnvm search /<regexp>/     :Search and Print <regexp> Node.js version detail.
 gnvm search latest         :Search and Print latest   Node.js version detail.
 gnvm search 0.10.10        :Search and Print 0.10.10  Node.js version detail.
+gnvm search 18.*.* --columns=version,openssl,modules :Search and Print Node.js version detail only include columns.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
-			P(ERROR, "%v must be one parameter, please check your input. See '%v'.\n", "gnvm search", "gnvm help search")
-		} else {
-			nodehandle.Search(args[0])
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one parameter, please check your input. See '%v'.\n", "gnvm search", "gnvm help search")
 		}
+		if columns != "" {
+			if err := nodehandle.SetColumns(columns); err != nil {
+				return util.Fail(util.EXIT_USAGE, ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help search")
+			}
+		}
+		return nodehandle.Search(args[0])
 	},
 }
 
@@ -422,20 +682,235 @@ gnvm npm x.xx.xx          :Install x.xx.xx npm version.
 gnvm npm latest           :Install latest  npm version.
 gnvm npm global           :Install local Node.js version matching npm version.
 `,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
-			P(ERROR, "%v must be one parameter and only support [%v] [%v] [%v] keyword, please check your input. See '%v'.\n", "gnvm npm", "latest", "global", "x.xx.xx", "gnvm help npm")
-		} else {
-			if _, ok := util.IsSessionEnv("npm", true); ok {
-				return
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one parameter and only support [%v] [%v] [%v] keyword, please check your input. See '%v'.\n", "gnvm npm", "latest", "global", "x.xx.xx", "gnvm help npm")
+		}
+		if err := sessionEnv("npm"); err != nil {
+			return err
+		}
+		util.EqualAbs("global", args[0])
+		util.EqualAbs("latest", args[0])
+		return nodehandle.InstallNPM(args[0])
+	},
+}
+
+// sub cmd
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check gnvm setup and print pass/warn/fail lines with fixes",
+	Long: `Check gnvm setup and print pass/warn/fail lines with fixes. e.g. :
+gnvm doctor               :Check noderoot, .gnvmrc, global and latest version, Path, NODE_HOME, npm, session and registry.
+gnvm --root x:\xxx doctor :Check assign noderoot.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			P(WARING, "'%v' no parameter, please check your input. See '%v'.\n", "gnvm doctor", "gnvm help doctor")
+		}
+		return nodehandle.Doctor()
+	},
+}
+
+// sub cmd
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import Node.js versions from nvm-windows, nvm, nodist or fnm",
+	Long: `Import Node.js versions already installed by other version manager, e.g. :
+gnvm import --from nvm-windows            :Import from %NVM_HOME%, and set %NVM_SYMLINK% version to global.
+gnvm import --from nvm ~/.nvm             :Import from assign folder, and set alias default version to global.
+gnvm import --from nodist                 :Import from %NODIST_PREFIX% v and v-x64 folders.
+gnvm import --from fnm --mode=link        :Import usage link, mode include: copy, move and link, default is copy.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm import", "one", "gnvm help import")
+		}
+		if !contains(manager.IMPORT_TOOLS, from) {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one of %v, please check your input. See '%v'.\n", "--from", strings.Join(manager.IMPORT_TOOLS, ", "), "gnvm help import")
+		}
+		if !contains(manager.IMPORT_MODES, mode) {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be one of %v, please check your input. See '%v'.\n", "--mode", strings.Join(manager.IMPORT_MODES, ", "), "gnvm help import")
+		}
+		if err := sessionEnv("import"); err != nil {
+			return err
+		}
+		dir := ""
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		return nodehandle.Import(from, dir, mode)
+	},
+}
+
+// sub cmd
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync local Node.js versions by gnvm.json or gnvm.toml manifest",
+	Long: `Sync local Node.js versions, global, latest and npm by manifest, e.g. :
+gnvm sync                                 :Sync by gnvm.json or gnvm.toml of current folder.
+gnvm sync x:\team\gnvm.toml              :Sync by assign manifest.
+gnvm sync --prune                         :Sync and remove local Node.js versions which not in manifest.
+gnvm sync --dry-run                       :Only print diff, '+' is install, '-' is remove, '~' is change.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm sync", "one", "gnvm help sync")
+		}
+		if !dryRun {
+			if err := sessionEnv("sync"); err != nil {
+				return err
+			}
+		}
+		path := ""
+		if len(args) == 1 {
+			path = args[0]
+		}
+		return nodehandle.Sync(path, prune, dryRun)
+	},
+}
+
+// sub cmd
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export lockfile of installed Node.js versions and SHA-256 checksums",
+	Long: `Export lockfile of installed Node.js versions, arch, registry, SHA-256, global, latest and npm, e.g. :
+gnvm export                               :Export to gnvm-lock.json of current folder.
+gnvm export x:\audit\gnvm-lock.json       :Export to assign lockfile.
+gnvm export --verify                      :Verify current machine by gnvm-lock.json, exit code is 5 when different.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm export", "one", "gnvm help export")
+		}
+		path := ""
+		if len(args) == 1 {
+			path = args[0]
+		}
+		return nodehandle.Export(path, verify)
+	},
+}
+
+// sub cmd
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify installed Node.js versions by SHA-256 and node --version",
+	Long: `Verify installed Node.js versions by SHASUMS256.txt or recorded SHA-256, and node --version, e.g. :
+gnvm verify                               :Verify all Node.js versions and global node.exe.
+gnvm verify 20.1.0                        :Verify 20.1.0 Node.js version.
+gnvm verify all --repair                  :Verify all and redownload corrupted Node.js versions.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm verify", "one", "gnvm help verify")
+		}
+		if repair {
+			if err := sessionEnv("verify --repair"); err != nil {
+				return err
 			}
-			util.EqualAbs("global", args[0])
-			util.EqualAbs("latest", args[0])
-			nodehandle.InstallNPM(args[0])
 		}
+		version := ""
+		if len(args) == 1 {
+			version = args[0]
+		}
+		return nodehandle.Verify(version, repair)
+	},
+}
+
+// sub cmd
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old Node.js versions by keep policies",
+	Long: `Remove old Node.js versions by keep policies, global, latest and gnvm.json or gnvm.toml versions are always protected, e.g. :
+gnvm prune --keep 5                       :Keep the newest 5 Node.js versions.
+gnvm prune --keep-latest-per-major 1      :Keep the newest Node.js version of each major.
+gnvm prune --older-than 180d              :Remove Node.js versions installed 180 days ago.
+gnvm prune --unused-since 2024-01-01      :Remove Node.js versions not used by 'gnvm use' since 2024-01-01.
+gnvm prune --keep 3 --dry-run             :Only print Node.js versions would be removed and free space.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "'%v' no parameter, please check your input. See '%v'.\n", "gnvm prune", "gnvm help prune")
+		}
+		if keep < 0 || major < 0 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "flag %v and %v must be positive number. See '%v'.\n", "--keep", "--keep-latest-per-major", "gnvm help prune")
+		}
+		if keep == 0 && major == 0 && older == "" && unused == "" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "'%v' need at least one policy, include: %v. See '%v'.\n", "gnvm prune", "--keep, --keep-latest-per-major, --older-than and --unused-since", "gnvm help prune")
+		}
+		if !dryRun {
+			if err := sessionEnv("prune"); err != nil {
+				return err
+			}
+		}
+		return nodehandle.Prune(keep, major, older, unused, dryRun)
+	},
+}
+
+// sub cmd
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Compare local Node.js versions to the newest patch in each line",
+	Long: `Compare local Node.js versions to the newest remote patch in the same line, flag security releases and end-of-life lines, e.g. :
+gnvm outdated                             :Print local Node.js versions next to the newest patch, line is major, 0.x is minor.
+gnvm outdated --json                      :Print as json, include: folder, version, latest, target, line, security, eol and end.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "'%v' no parameter, please check your input. See '%v'.\n", "gnvm outdated", "gnvm help outdated")
+		}
+		return nodehandle.Outdated()
+	},
+}
+
+// sub cmd
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Install the newest patch in the line of local Node.js versions",
+	Long: `Install the newest remote patch in the same line of local Node.js versions, old versions are not removed, e.g. :
+gnvm upgrade 18.16.0                      :Install the newest v18 patch, e.g. 18.20.4.
+gnvm upgrade all                          :Install the newest patch of all outdated Node.js versions.
+gnvm upgrade global --migrate             :Install the newest patch of global, and move global and latest to it.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm upgrade", "one", "gnvm help upgrade")
+		}
+		if err := sessionEnv("upgrade"); err != nil {
+			return err
+		}
+		return nodehandle.Upgrade(args[0], migrate)
 	},
 }
 
+// sub cmd
+var migrateGlobalsCmd = &cobra.Command{
+	Use:   "migrate-globals",
+	Short: "Reinstall global npm packages of one Node.js version to another",
+	Long: `Reinstall global npm packages of <from> Node.js version to <to> Node.js version, usage npm of <to>, e.g. :
+gnvm migrate-globals 18.16.0 20.1.0       :Reinstall global npm packages of 18.16.0 to 20.1.0 at pinned versions.
+gnvm migrate-globals global latest        :Reinstall global npm packages of global version to latest version.
+gnvm migrate-globals 18.16.0 global --latest-packages :Reinstall latest versions of the packages.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v must be only %v parameter, please check your input. See '%v'.\n", "gnvm migrate-globals", "two", "gnvm help migrate-globals")
+		}
+		if err := sessionEnv("migrate-globals"); err != nil {
+			return err
+		}
+		return nodehandle.MigrateGlobals(args[0], args[1], packLatest)
+	},
+}
+
+func contains(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func init() {
 
 	// add sub cmd to root
@@ -451,18 +926,89 @@ func init() {
 	gnvmCmd.AddCommand(nodeVersionCmd)
 	gnvmCmd.AddCommand(regCmd)
 	gnvmCmd.AddCommand(versionCmd)
+	gnvmCmd.AddCommand(doctorCmd)
+	gnvmCmd.AddCommand(importCmd)
+	gnvmCmd.AddCommand(syncCmd)
+	gnvmCmd.AddCommand(exportCmd)
+	gnvmCmd.AddCommand(verifyCmd)
+	gnvmCmd.AddCommand(pruneCmd)
+	gnvmCmd.AddCommand(outdatedCmd)
+	gnvmCmd.AddCommand(upgradeCmd)
+	gnvmCmd.AddCommand(migrateGlobalsCmd)
 
 	// flag
 	installCmd.PersistentFlags().BoolVarP(&global, "global", "g", false, "set this version global version.")
+	installCmd.PersistentFlags().StringVar(&from, "from", "", "install Node.js from local archive or url, include: .zip and node.exe.")
+	importCmd.PersistentFlags().StringVar(&from, "from", "", "version manager, include: nvm-windows, nvm, nodist and fnm.")
+	importCmd.PersistentFlags().StringVar(&mode, "mode", manager.IMPORT_COPY, "import mode, include: copy, move and link.")
+	syncCmd.PersistentFlags().BoolVar(&prune, "prune", false, "remove local Node.js versions which not in manifest.")
+	syncCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "only print diff, not install or remove.")
+	exportCmd.PersistentFlags().BoolVar(&verify, "verify", false, "verify current machine by lockfile, not write.")
+	verifyCmd.PersistentFlags().BoolVar(&repair, "repair", false, "redownload corrupted Node.js versions and copy global node.exe again.")
+	pruneCmd.PersistentFlags().IntVar(&keep, "keep", 0, "keep the newest N Node.js versions.")
+	pruneCmd.PersistentFlags().IntVar(&major, "keep-latest-per-major", 0, "keep the newest N Node.js versions of each major.")
+	pruneCmd.PersistentFlags().StringVar(&older, "older-than", "", "remove Node.js versions installed before, e.g. 180d 2w 2024-01-01.")
+	pruneCmd.PersistentFlags().StringVar(&unused, "unused-since", "", "remove Node.js versions not used since, e.g. 90d 2024-01-01.")
+	pruneCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "only print Node.js versions would be removed and free space.")
+	upgradeCmd.PersistentFlags().BoolVar(&migrate, "migrate", false, "move global and latest to the new patch.")
+	installCmd.PersistentFlags().StringVar(&packFrom, "reinstall-packages-from", "", "reinstall global npm packages of this Node.js version, e.g. global 18.16.0.")
+	installCmd.PersistentFlags().BoolVar(&packLatest, "latest-packages", false, "reinstall latest versions of global npm packages instead of pinned versions.")
+	migrateGlobalsCmd.PersistentFlags().BoolVar(&packLatest, "latest-packages", false, "reinstall latest versions of global npm packages instead of pinned versions.")
+	installCmd.PersistentFlags().StringVar(&as, "as", "", "install --from Node.js to this folder name, e.g. 18.19.0-patched.")
 	updateCmd.PersistentFlags().BoolVarP(&global, "global", "g", false, "set this version global version.")
 	lsCmd.PersistentFlags().BoolVarP(&remote, "remote", "r", false, "get remote all node.js version list.")
 	lsCmd.PersistentFlags().BoolVarP(&detail, "detail", "d", false, "get remote all node.js version details list.")
 	lsCmd.PersistentFlags().IntVarP(&limit, "limit", "l", 0, "get remote all node.js version details list by limit count.")
+	lsCmd.PersistentFlags().BoolVarP(&long, "long", "L", false, "print local Node.js version list with arch, size, install date, registry, npm, lts and last used.")
 	lsCmd.PersistentFlags().BoolVarP(&io, "io", "i", false, "get remote all io.js version details list.")
+	lsCmd.PersistentFlags().StringVar(&channel, "channel", "", "get remote Node.js version list of release channel, include: release, rc, nightly, test and custom channel.")
+	lsCmd.PersistentFlags().StringVar(&columns, "columns", "", "print columns, include: no, date, version, exec, npm, lts, security, v8, uv, zlib, openssl, modules, files and all.")
+	searchCmd.PersistentFlags().StringVar(&columns, "columns", "", "print columns, include: no, date, version, exec, npm, lts, security, v8, uv, zlib, openssl, modules, files and all.")
 	//nodeVersionCmd.PersistentFlags().BoolVarP(&remote, "remote", "r", false, "get remote node.js latest version.")
 	versionCmd.PersistentFlags().BoolVarP(&remote, "remote", "r", false, "get remote gnvm latest version.")
 	versionCmd.PersistentFlags().BoolVarP(&detail, "detail", "d", false, "get remote CHANGELOG.")
+	gnvmCmd.PersistentFlags().BoolVar(&jsonFmt, "json", false, "print result as json, include: ls, search, node-version, config and version.")
+	gnvmCmd.PersistentFlags().StringVar(&format, "format", util.FORMAT_TEXT, "print result format, include: text, json and tsv.")
+	configCmd.PersistentFlags().BoolVar(&explain, "explain", false, "print all config property and where each effective value came from.")
+	gnvmCmd.PersistentFlags().StringVar(&root, "root", "", "gnvm root path, priority is higher than GNVM_HOME environment variable.")
+	gnvmCmd.PersistentFlags().StringVar(&registry, "registry", "", "override config registry, not write .gnvmrc.")
+	gnvmCmd.PersistentFlags().StringArrayVar(&overrides, "config", nil, "override config property, e.g. --config timeout=30s, not write .gnvmrc.")
+	gnvmCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "only print results and errors.")
+	gnvmCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "print resolved paths and registry.")
+	gnvmCmd.PersistentFlags().BoolVar(&debug, "debug", false, "print verbose messages and http requests.")
+	gnvmCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color, the same as NO_COLOR environment variable.")
+	gnvmCmd.PersistentFlags().BoolVarP(&yes, "yes", "y", false, "answer yes to all prompts, e.g. gnvm npm and gnvm reg.")
+	gnvmCmd.PersistentFlags().BoolVar(&no, "no", false, "answer no to all prompts, e.g. gnvm npm and gnvm reg.")
+}
+
+/*
+Execute gnvm command
+
+Return:
+  - code: exit code, e.g. util.EXIT_USAGE
+*/
+func Execute() int {
+	// before read .gnvmrc, usage GNVM_LANG or LANG environment variable, e.g. gnvm -h
+	if err := SetLang(os.Getenv(config.EnvName(config.LANG))); err != nil {
+		SetLang("")
+	}
+	help := gnvmCmd.HelpFunc()
+	gnvmCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		translate(gnvmCmd)
+		help(cmd, args)
+	})
+
+	err := gnvmCmd.Execute()
+	if err == nil {
+		return util.EXIT_OK
+	}
 
-	// exec
-	gnvmCmd.Execute()
+	// cobra error, e.g. unknown command or flag, ExitError and VersionError already print
+	var exitErr *util.ExitError
+	var verErr *util.VersionError
+	if !errors.As(err, &exitErr) && !errors.As(err, &verErr) {
+		P(ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help")
+		return util.EXIT_USAGE
+	}
+	return util.ExitCode(err)
 }
@@ -0,0 +1,67 @@
+package command
+
+import (
+	// go
+	"strings"
+	"testing"
+
+	// lib
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	// local
+	. "gnvm/console"
+	"gnvm/util"
+)
+
+/*
+All help text of gnvm commands have Simplified Chinese translation
+*/
+func TestHelpCatalog(t *testing.T) {
+	defer SetLang(LANG_EN)
+	SetLang(LANG_ZH_CN)
+
+	var check func(cmd *cobra.Command)
+	check = func(cmd *cobra.Command) {
+		if T(cmd.Short) == cmd.Short {
+			t.Errorf("%v Short %q not translate", cmd.Name(), cmd.Short)
+		}
+		for _, line := range strings.Split(cmd.Long, "\n") {
+			if arr := strings.SplitN(line, " :", 2); len(arr) == 2 && arr[1] != "" {
+				line = arr[1]
+			}
+			if line != "" && !strings.HasPrefix(line, "Copyright") && T(line) == line {
+				t.Errorf("%v Long %q not translate", cmd.Name(), line)
+			}
+		}
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			if T(flag.Usage) == flag.Usage {
+				t.Errorf("%v flag %v usage %q not translate", cmd.Name(), flag.Name, flag.Usage)
+			}
+		})
+		for _, sub := range cmd.Commands() {
+			check(sub)
+		}
+	}
+	check(gnvmCmd)
+}
+
+/*
+gnvm ls -l is --limit, the same as before --long, --long is -L
+*/
+func TestLsShorthand(t *testing.T) {
+	for shorthand, name := range map[string]string{"l": "limit", "L": "long", "r": "remote", "d": "detail"} {
+		if flag := lsCmd.Flags().ShorthandLookup(shorthand); flag == nil || flag.Name != name {
+			t.Errorf("gnvm ls -%v is %+v, want --%v", shorthand, flag, name)
+		}
+	}
+}
+
+func TestJSONFormatConflict(t *testing.T) {
+	defer func() { jsonFmt, format = false, util.FORMAT_TEXT }()
+	gnvmCmd.SetArgs([]string{"--json", "--format", "tsv", "version"})
+	defer gnvmCmd.SetArgs(nil)
+	if err := gnvmCmd.Execute(); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("gnvm --json --format tsv version, err %v", err)
+	}
+}
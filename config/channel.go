@@ -0,0 +1,149 @@
+package config
+
+import (
+	// lib
+	"github.com/tsuru/config"
+
+	// go
+	"fmt"
+	"sort"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Custom channel config property prefix, e.g. channel.beta: https://example.com/beta/
+*/
+const CHANNEL_PREFIX = "channel."
+
+/*
+Built-in channel url of known registry
+*/
+var mirrorChannels = map[string]map[string]string{
+	util.ORIGIN_DEFAULT: {
+		util.CHANNEL_RC:      "https://nodejs.org/download/rc/",
+		util.CHANNEL_NIGHTLY: "https://nodejs.org/download/nightly/",
+		util.CHANNEL_TEST:    "https://nodejs.org/download/test/",
+	},
+	util.ORIGIN_TAOBAO: {
+		util.CHANNEL_RC:      "https://cdn.npmmirror.com/binaries/node-rc/",
+		util.CHANNEL_NIGHTLY: "https://cdn.npmmirror.com/binaries/node-nightly/",
+	},
+}
+
+/*
+Return channel url, priority is config property channel.<name> > built-in url of registry > registry <url>/dist/ to <url>/download/<name>/
+
+Param:
+  - name: channel name, e.g. release rc nightly test beta
+
+Return:
+  - url:   e.g. https://nodejs.org/download/nightly/
+  - error: channel not configured of current registry
+*/
+func ChannelURL(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	registry := GetConfig(REGISTRY)
+	if name == "" || name == util.CHANNEL_RELEASE {
+		return registry, nil
+	}
+	if channelKey(CHANNEL_PREFIX+name) != nil {
+		if value, _ := Explain(CHANNEL_PREFIX + name); value != "" {
+			return value, nil
+		}
+	}
+	if url, ok := mirrorChannels[registry][name]; ok {
+		return url, nil
+	}
+	if builtin(name) && strings.HasSuffix(registry, "/dist/") {
+		return strings.TrimSuffix(registry, "dist/") + "download/" + name + "/", nil
+	}
+	return "", fmt.Errorf("channel %v not configured of registry %v, please use 'gnvm config %v <url>'", name, registry, CHANNEL_PREFIX+name)
+}
+
+/*
+Return all available channel url of current registry, key is channel name, not include release
+*/
+func Channels() map[string]string {
+	channels := make(map[string]string)
+	for _, name := range ChannelNames() {
+		if url, err := ChannelURL(name); err == nil {
+			channels[name] = url
+		}
+	}
+	return channels
+}
+
+/*
+Return built-in and custom channel names, sort by name, not include release
+*/
+func ChannelNames() []string {
+	names := []string{util.CHANNEL_NIGHTLY, util.CHANNEL_RC, util.CHANNEL_TEST}
+	for _, key := range customKeys() {
+		if name := strings.TrimPrefix(key, CHANNEL_PREFIX); !builtin(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+Return custom channel config property names from .gnvmrc, user config and environment variable, e.g. channel.beta
+*/
+func customKeys() []string {
+	keys, exist := []string{}, make(map[string]bool)
+	for _, data := range []map[interface{}]interface{}{config.DefaultConfig.Data(), userConfig.Data()} {
+		for k := range data {
+			if key := fmt.Sprint(k); strings.HasPrefix(key, CHANNEL_PREFIX) && !exist[key] {
+				keys, exist[key] = append(keys, key), true
+			}
+		}
+	}
+	for key := range envs {
+		if strings.HasPrefix(key, CHANNEL_PREFIX) && !exist[key] {
+			keys, exist[key] = append(keys, key), true
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*
+Return all config property schema, include Keys and custom channel properties
+*/
+func allKeys() []Key {
+	keys := append([]Key{}, Keys...)
+	for _, name := range customKeys() {
+		if key := channelKey(name); key != nil {
+			keys = append(keys, *key)
+		}
+	}
+	return keys
+}
+
+/*
+Return config property schema of custom channel
+
+Param:
+  - name: config property name, e.g. channel.beta
+
+Return:
+  - *Key: when name is not valid channel property, return nil
+*/
+func channelKey(name string) *Key {
+	channel := strings.TrimPrefix(name, CHANNEL_PREFIX)
+	if !strings.HasPrefix(name, CHANNEL_PREFIX) || channel == util.CHANNEL_RELEASE {
+		return nil
+	}
+	if c, _, _, ok := util.ParseChannel(channel + "/" + util.LATEST); !ok || c != channel {
+		return nil
+	}
+	return &Key{name, KIND_URL, "", "Node.js " + channel + " channel download url."}
+}
+
+func builtin(name string) bool {
+	return name == util.CHANNEL_RC || name == util.CHANNEL_NIGHTLY || name == util.CHANNEL_TEST
+}
@@ -2,23 +2,20 @@ package config
 
 import (
 	// lib
-	"io"
-
-	. "github.com/Kenshin/cprint"
 	"github.com/tsuru/config"
 
 	// go
-	"bufio"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
-	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
 	// local
+	. "gnvm/console"
 	"gnvm/util"
 )
 
@@ -48,13 +45,19 @@ const (
 	//CURRENT_VERSION_VAL = UNKNOWN
 )
 
-func init() {
+/*
+Read .gnvmrc, user config and environment variables, must be call after util.SetRoot()
+
+Return:
+  - err: *util.ExitError, code is util.EXIT_CONFIG
+*/
+func Init() (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
-			Error(ERROR, "gnvm.exe an error has occurred. please check. \nError: ", err)
-			os.Exit(0)
+		if e := recover(); e != nil {
+			Error(ERROR, "gnvm.exe an error has occurred. please check. \nError: ", e)
+			err = util.Errorf(util.EXIT_CONFIG, "%v", e)
 		}
 	}()
 
@@ -66,12 +69,23 @@ func init() {
 	defer file.Close()
 	if err != nil && os.IsNotExist(err) {
 		P(WARING, "config file %v is not exist.\n", configPath)
-		createConfig()
+		if util.RootSource == util.ROOT_CWD {
+			P(WARING, "gnvm root is resolved by %v, not create config file, please set %v or use %v. See '%v'.\n", util.ROOT_CWD, util.GNVM_HOME, "--root", "gnvm doctor")
+		} else {
+			createConfig()
+		}
 	}
 
 	// read config
 	readConfig()
 
+	// read user config and environment variables
+	readLayers()
+
+	// set proxy and timeout
+	applyTransport()
+
+	return nil
 }
 
 /*
@@ -116,6 +130,9 @@ func createConfig() {
 Read .gnvmrc file
 */
 func readConfig() {
+	if !util.IsDirExist(configPath) {
+		return
+	}
 	if err := config.ReadConfigFile(configPath); err != nil {
 		P(ERROR, "read config file fail, please use '%v'. \nError: %v\n", "gnvm config INIT", err.Error())
 		return
@@ -126,63 +143,193 @@ func readConfig() {
 Write config property value from .gnvmrc file
 
 Param:
-  - key:   config property, include: registry noderoot latestversion globalversion
+  - key:   config property, include: registry noderoot latestversion globalversion proxy timeout
   - value: config property value
+
+Return:
+  - value: normalize value, when value is "", set fail
 */
 func SetConfig(key string, value interface{}) string {
-	if key == "registry" {
-		if !strings.HasPrefix(value.(string), "https://") && !strings.HasPrefix(value.(string), "http://") {
-			P(WARING, "%v need %v", value.(string), "https:// or http://", "\n")
-			if strings.HasPrefix(value.(string), "https://") {
-				value = "https://" + value.(string)
-			} else {
-				value = "http://" + value.(string)
-			}
+	k, err := Lookup(key)
+	if err != nil {
+		P(ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help config")
+		return ""
+	}
 
-		}
-		if !strings.HasSuffix(value.(string), "/") {
-			value = value.(string) + "/"
-		}
-		reg, _ := regexp.Compile(`^(https|http)?:\/\/(w{3}\.)?([-a-zA-Z0-9.])+(\.[a-zA-Z]+)(:\d{1,4})?(\/)+`)
-		if !reg.MatchString(value.(string)) {
-			P(ERROR, "%v value %v must valid url.\n", "registry", value.(string))
-			return ""
-		}
+	newValue, err := k.Validate(fmt.Sprint(value))
+	if err != nil {
+		P(ERROR, "%v.\n", err.Error())
+		return ""
 	}
 
 	// set new value
-	config.Set(key, value)
+	config.Set(key, newValue)
 
-	// delete old config
-	if err := os.Remove(configPath); err != nil {
-		P(ERROR, "remove config file Error: %v\n", err.Error())
+	// write new config
+	if err := writeConfig(); err != nil {
+		return ""
 	}
 
-	// write new config
-	if err := config.WriteConfigFile(configPath, 0777); err != nil {
-		P(ERROR, "write config file Error: %v\n", err.Error())
+	applyTransport()
+
+	if _, source := Explain(key); source != SOURCE_FILE {
+		P(WARING, "%v is overridden by %v, effective value is %v. See '%v'.\n", key, source, GetConfig(key), "gnvm config --explain")
 	}
 
-	return value.(string)
+	return newValue
 }
 
 /*
-Read config property value from .gnvmrc file
+Remove config property from .gnvmrc file, restore default value
 
 Param:
-  - key:   config property, include: registry noderoot latestversion globalversion
+  - key:   config property, include: registry noderoot latestversion globalversion proxy timeout
 
 Return:
-  - value: config property value
+  - value: default value
+  - error
 */
-func GetConfig(key string) string {
-	value, err := config.GetString(key)
+func UnsetConfig(key string) (string, error) {
+	k, err := Lookup(key)
 	if err != nil {
-		value = util.UNKNOWN
+		return "", err
+	}
+
+	value := k.DefaultValue()
+	if value == "" {
+		config.Unset(key)
+	} else {
+		config.Set(key, value)
 	}
+
+	if err := writeConfig(); err != nil {
+		return "", err
+	}
+
+	applyTransport()
+
+	return value, nil
+}
+
+/*
+Read config property effective value
+
+Param:
+  - key:   config property, include: registry noderoot latestversion globalversion proxy timeout
+
+Return:
+  - value: config property effective value, priority is flag > env > user > .gnvmrc > default, when key invalid return util.UNKNOWN
+*/
+func GetConfig(key string) string {
+	if _, err := Lookup(key); err != nil {
+		return util.UNKNOWN
+	}
+	value, _ := Explain(key)
 	return value
 }
 
+/*
+Load typed config from .gnvmrc file, invalid value usage default value
+
+Return:
+  - *Config
+*/
+func Load() *Config {
+	get := func(key string) string {
+		k, _ := Lookup(key)
+		if value, err := k.Validate(GetConfig(key)); err == nil && value != "" {
+			return value
+		}
+		return k.DefaultValue()
+	}
+	timeout, _ := time.ParseDuration(get(TIMEOUT))
+	return &Config{
+		Registry:      get(REGISTRY),
+		NodeRoot:      get(NODEROOT),
+		GlobalVersion: get(GLOBAL_VERSION),
+		LatestVersion: get(LATEST_VERSION),
+		Proxy:         GetConfig(PROXY),
+		Timeout:       timeout,
+		Lang:          get(LANG),
+		Schedule:      get(SCHEDULE),
+		Silence:       Silenced(),
+	}
+}
+
+/*
+Print all config property value by util.Format( json or tsv )
+*/
+func JSON() {
+	keys := allKeys()
+	values, rows := make(map[string]string, len(keys)), make([][]string, 0, len(keys))
+	for _, key := range keys {
+		value, source := Explain(key.Name)
+		values[key.Name] = value
+		rows = append(rows, []string{key.Name, value, source})
+	}
+	util.PrintDoc(values, []string{"key", "value", "source"}, rows)
+}
+
+/*
+Parse .gnvmrc file and validate all property value, usage 'gnvm doctor'
+
+Return:
+  - path: .gnvmrc path
+  - errs: parse error or invalid property value error
+*/
+func Check() (string, []error) {
+	var errs []error
+	file := new(config.Configuration)
+	if err := file.ReadConfigFile(configPath); err != nil {
+		return configPath, append(errs, err)
+	}
+	for _, key := range allKeys() {
+		value, err := file.Get(key.Name)
+		if err != nil || value == nil {
+			continue
+		}
+		if _, err := key.Validate(fmt.Sprint(value)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return configPath, errs
+}
+
+/*
+Remove and write .gnvmrc file
+*/
+func writeConfig() error {
+	// delete old config
+	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+		P(ERROR, "remove config file Error: %v\n", err.Error())
+	}
+
+	// write new config
+	if err := config.WriteConfigFile(configPath, 0777); err != nil {
+		P(ERROR, "write config file Error: %v\n", err.Error())
+		return err
+	}
+	return nil
+}
+
+/*
+Set http.DefaultTransport proxy and response header timeout from .gnvmrc
+*/
+func applyTransport() {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return
+	}
+	cfg := Load()
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		if u, err := url.Parse(cfg.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(u)
+		}
+	}
+	transport.ResponseHeaderTimeout = cfg.Timeout
+}
+
 /*
 Init config property value from .gnvmrc file
 */
@@ -216,48 +363,23 @@ Print all config property value from .gnvmrc file
 */
 func List() {
 	P(NOTICE, "config file path %v \n", configPath)
-	f, err := os.Open(configPath)
-	if err != nil {
-		P(ERROR, "read config file fail, please use '%v'. \nError: %v\n", "gnvm config INIT", err.Error())
-		return
+	if _, err := os.Stat(configPath); err != nil {
+		P(WARING, "read config file fail, please use '%v'. \nError: %v\n", "gnvm config INIT", err.Error())
 	}
-	buf := bufio.NewReader(f)
-	for {
-		line, _, err := buf.ReadLine()
-		if err == io.EOF {
-			break
-		}
-		arr := strings.SplitN(string(line), ":", 2)
-		if len(arr) == 2 {
-			P(DEFAULT, "gnvm config %v is %v\n", strings.TrimSpace(arr[0]), strings.TrimSpace(arr[1]))
-		}
+	for _, key := range allKeys() {
+		P(DEFAULT, "gnvm config %v is %v\n", key.Name, GetConfig(key.Name))
 	}
 }
 
-/*
-Get io.js url
-
-Param:
-  - url:   config property, include: registry noderoot latestversion globalversion
-
-Return:
-  - value: config property value
-*/
-func GetIOURL(url string) string {
-	if url == util.ORIGIN_TAOBAO {
-		url = strings.Replace(url, "/node", "/iojs", -1)
-	} else if url == util.ORIGIN_DEFAULT {
-		url = strings.Replace(url, "nodejs.org", "iojs.org", -1)
-	}
-	return url
-}
-
 /*
 Verify config registry url structural correctness, include:
   - url:  <url>
   - json: <url>/index.json
+
+Return:
+  - err: *util.ExitError, code is util.EXIT_NETWORK
 */
-func Verify() {
+func Verify() error {
 	code := make(chan int)
 	fail := make(chan interface{})
 	finish := false
@@ -279,12 +401,12 @@ func Verify() {
 
 	for {
 		select {
-		case <-time.After(time.Second * 10):
+		case <-time.After(Load().Timeout):
 			cp1 := CP{Red, false, None, false, "fail"}
 			cp2 := CP{Red, false, None, false, "time out"}
 			P(DEFAULT, "%v. \n", cp1)
 			P(ERROR, "gnvm config registry %v vaild %v, Error: %v.", registry, cp1, cp2)
-			return
+			return util.Errorf(util.EXIT_NETWORK, "gnvm config registry %v vaild fail, Error: time out", registry)
 		case value, ok := <-code:
 			if ok && value == 200 {
 				finish = true
@@ -294,7 +416,7 @@ func Verify() {
 				go wait()
 			} else if !ok {
 				P(DEFAULT, "%v.\n", CP{Magenta, false, None, false, " ok"})
-				return
+				return nil
 			}
 		case value, _ := <-fail:
 			cp := CP{Red, false, None, false, " fail"}
@@ -306,7 +428,7 @@ func Verify() {
 			}
 			close(fail)
 			finish = true
-			return
+			return util.Errorf(util.EXIT_NETWORK, "gnvm config registry %v vaild fail, Error: %v", registry, value)
 		}
 	}
 }
@@ -314,7 +436,7 @@ func Verify() {
 func verifyURL(status string, url string, code chan int, fail chan interface{}) {
 	P(NOTICE, "gnvm config registry %v valid ", url)
 	time.Sleep(time.Second * 2)
-	if resp, err := http.Get(url); err == nil {
+	if resp, err := util.HTTPClient.Get(url); err == nil {
 		if resp.StatusCode == 200 {
 			if status == "url" {
 				code <- resp.StatusCode
@@ -328,3 +450,18 @@ func verifyURL(status string, url string, code chan int, fail chan interface{})
 		fail <- err
 	}
 }
+
+/*
+Return silence Node.js versions of config, not warn end-of-life and security release
+
+Return:
+  - versions: e.g. [16.20.0 18.16.0-x86], when not set, return nil
+*/
+func Silenced() []string {
+	k, _ := Lookup(SILENCE)
+	value, err := k.Validate(GetConfig(SILENCE))
+	if err != nil || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	// go
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	// local
+	"gnvm/internal/fake"
+	"gnvm/util"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+		ok               bool
+	}{
+		{REGISTRY, "https://npmmirror.com/node", "https://npmmirror.com/node/", true},
+		{REGISTRY, "npmmirror.com/node", "", false},
+		{REGISTRY, "ftp://npmmirror.com/node/", "", false},
+		{REGISTRY, "", "", false},
+		{PROXY, "", "", true},
+		{GLOBAL_VERSION, "5.10.1-X86", "5.10.1-x86", true},
+		{GLOBAL_VERSION, "5.10", "", false},
+		{TIMEOUT, "1m", "1m0s", true},
+		{TIMEOUT, "-1s", "", false},
+		{NODEROOT, "relative", "", false},
+		{LANG, "zh_CN.UTF-8", "zh-CN", true},
+		{LANG, "", "", true},
+		{LANG, "fr", "", false},
+		{SILENCE, "16.20.0, 18.16.0-X86,16.20.0", "16.20.0,18.16.0-x86", true},
+		{SILENCE, "16.20", "", false},
+		{SILENCE, "", "", true},
+	}
+	for _, test := range tests {
+		key, err := Lookup(test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := key.Validate(test.value)
+		if (err == nil) != test.ok || (test.ok && got != test.want) {
+			t.Errorf("Validate(%v, %v) = %v, %v, want %v", test.key, test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if _, err := Lookup("registy"); err == nil || !strings.Contains(err.Error(), REGISTRY) {
+		t.Fatalf("Lookup(registy) err %v, want suggest %v", err, REGISTRY)
+	}
+}
+
+func TestChannelURL(t *testing.T) {
+	root, _ := fake.Root(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	path, source := util.GlobalNodePath, util.RootSource
+	t.Cleanup(func() { util.GlobalNodePath, util.RootSource = path, source })
+	if err := util.SetRoot(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	if url, err := ChannelURL(util.CHANNEL_NIGHTLY); err != nil || url != "https://nodejs.org/download/nightly/" {
+		t.Fatalf("ChannelURL(nightly) = %v, %v", url, err)
+	}
+	if _, err := ChannelURL("beta"); err == nil {
+		t.Fatal("ChannelURL(beta) not configured, err is nil")
+	}
+	if SetConfig(CHANNEL_PREFIX+"beta", "https://example.com/beta") == "" {
+		t.Fatal("set channel.beta fail")
+	}
+	if url, err := ChannelURL("beta"); err != nil || url != "https://example.com/beta/" {
+		t.Fatalf("ChannelURL(beta) = %v, %v", url, err)
+	}
+	if names := strings.Join(ChannelNames(), " "); names != "beta nightly rc test" {
+		t.Fatalf("ChannelNames() = %v", names)
+	}
+	if SetConfig(REGISTRY, "https://example.com/mirror/") == "" {
+		t.Fatal("set registry fail")
+	}
+	if _, err := ChannelURL(util.CHANNEL_RC); err == nil {
+		t.Fatal("ChannelURL(rc) of custom registry, err is nil")
+	}
+	for _, name := range []string{"channel.release", "channel.", "channel.Beta!"} {
+		if _, err := Lookup(name); err == nil {
+			t.Errorf("Lookup(%v) err is nil", name)
+		}
+	}
+}
+
+func TestLayers(t *testing.T) {
+	root, _ := fake.Root(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+	t.Setenv(EnvName(TIMEOUT), "30s")
+
+	path, source := util.GlobalNodePath, util.RootSource
+	t.Cleanup(func() { util.GlobalNodePath, util.RootSource = path, source })
+	if err := util.SetRoot(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, CONFIG) {
+		t.Fatal(".gnvmrc not created")
+	}
+
+	if value, source := Explain(TIMEOUT); value != "30s" || source != SOURCE_ENV {
+		t.Fatalf("Explain(timeout) = %v, %v", value, source)
+	}
+	if SetConfig(REGISTRY, "http://127.0.0.1/dist") == "" {
+		t.Fatal("SetConfig(registry) fail")
+	}
+	if value, source := Explain(REGISTRY); value != "http://127.0.0.1/dist/" || source != SOURCE_FILE {
+		t.Fatalf("Explain(registry) = %v, %v", value, source)
+	}
+	if err := Override(REGISTRY, "http://127.0.0.2/dist/"); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(flags, REGISTRY)
+	if value, source := Explain(REGISTRY); value != "http://127.0.0.2/dist/" || source != SOURCE_FLAG {
+		t.Fatalf("Explain(registry) = %v, %v", value, source)
+	}
+	if cfg := Load(); cfg.Timeout.String() != "30s" || cfg.Registry != "http://127.0.0.2/dist/" {
+		t.Fatalf("Load() = %+v", cfg)
+	}
+	if _, errs := Check(); len(errs) > 0 {
+		t.Fatalf("Check() = %v", errs)
+	}
+}
+
+func TestUserLayer(t *testing.T) {
+	root, _ := fake.Root(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if err := os.MkdirAll(filepath.Join(home, ".gnvm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "registry: nope\ntimeout: 1m\nchannel.beta: https://example.com/beta\nchannel.alpha: nope\n"
+	if err := os.WriteFile(filepath.Join(home, ".gnvm", CONFIG), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, source := util.GlobalNodePath, util.RootSource
+	t.Cleanup(func() { util.GlobalNodePath, util.RootSource = path, source })
+	if err := util.SetRoot(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, source := Explain(REGISTRY); value != util.ORIGIN_DEFAULT || source == SOURCE_USER {
+		t.Fatalf("Explain(registry) = %v, %v", value, source)
+	}
+	if value, source := Explain(TIMEOUT); value != "1m0s" || source != SOURCE_USER {
+		t.Fatalf("Explain(timeout) = %v, %v", value, source)
+	}
+	if url, err := ChannelURL("beta"); err != nil || url != "https://example.com/beta/" {
+		t.Fatalf("ChannelURL(beta) = %v, %v", url, err)
+	}
+	if _, err := ChannelURL("alpha"); err == nil {
+		t.Fatal("ChannelURL(alpha) invalid user config, err is nil")
+	}
+}
+
+func TestChannelEnv(t *testing.T) {
+	root, _ := fake.Root(t)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+	t.Setenv(EnvName(CHANNEL_PREFIX+"beta"), "https://example.com/beta")
+	t.Setenv(EnvName(CHANNEL_PREFIX+util.CHANNEL_RC), "https://example.com/rc/")
+	t.Setenv(EnvName(CHANNEL_PREFIX+"alpha"), "nope")
+
+	path, source := util.GlobalNodePath, util.RootSource
+	t.Cleanup(func() { util.GlobalNodePath, util.RootSource = path, source })
+	if err := util.SetRoot(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	if name := EnvName(CHANNEL_PREFIX + "beta"); name != "GNVM_CHANNEL_BETA" {
+		t.Fatalf("EnvName(channel.beta) = %v", name)
+	}
+	if value, source := Explain(CHANNEL_PREFIX + "beta"); value != "https://example.com/beta/" || source != SOURCE_ENV {
+		t.Fatalf("Explain(channel.beta) = %v, %v", value, source)
+	}
+	if url, err := ChannelURL(util.CHANNEL_RC); err != nil || url != "https://example.com/rc/" {
+		t.Fatalf("ChannelURL(rc) = %v, %v", url, err)
+	}
+	if _, err := ChannelURL("alpha"); err == nil {
+		t.Fatal("ChannelURL(alpha) invalid environment variable, err is nil")
+	}
+	if names := strings.Join(ChannelNames(), " "); names != "beta nightly rc test" {
+		t.Fatalf("ChannelNames() = %v", names)
+	}
+}
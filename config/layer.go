@@ -0,0 +1,187 @@
+package config
+
+import (
+	// lib
+	"github.com/tsuru/config"
+
+	// go
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// local
+	. "gnvm/console"
+	"gnvm/util"
+)
+
+/*
+Config property source, priority from low to high:
+  - SOURCE_DEFAULT: built-in default value
+  - SOURCE_FILE:    <noderoot>/.gnvmrc
+  - SOURCE_USER:    <home>/.gnvm/.gnvmrc
+  - SOURCE_ENV:     GNVM_<KEY> environment variable, e.g. GNVM_REGISTRY
+  - SOURCE_FLAG:    --config <key>=<value> or --registry <value> command-line flag
+*/
+const (
+	SOURCE_DEFAULT = "default"
+	SOURCE_FILE    = "file"
+	SOURCE_USER    = "user"
+	SOURCE_ENV     = "env"
+	SOURCE_FLAG    = "flag"
+
+	ENV_PREFIX = "GNVM_"
+)
+
+var (
+	userPath   string
+	userConfig config.Configuration
+	envs       = make(map[string]string)
+	flags      = make(map[string]string)
+)
+
+/*
+Read <home>/.gnvm/.gnvmrc and GNVM_<KEY> environment variables
+*/
+func readLayers() {
+	envs = make(map[string]string)
+	userConfig.Store(make(map[interface{}]interface{}))
+	if home, err := os.UserHomeDir(); err == nil {
+		userPath = filepath.Join(home, ".gnvm", CONFIG)
+		if util.IsDirExist(userPath) {
+			if err := userConfig.ReadConfigFile(userPath); err != nil {
+				P(WARING, "read user config file %v fail, Error: %v\n", userPath, err.Error())
+			}
+		}
+	}
+
+	// validate user config the same as environment variable, invalid value fall back to lower layer
+	for _, key := range allKeys() {
+		value, err := userConfig.Get(key.Name)
+		if err != nil || value == nil {
+			continue
+		}
+		if newValue, err := key.Validate(fmt.Sprint(value)); err != nil {
+			P(WARING, "user config %v of %v ignored, Error: %v.\n", key.Name, userPath, err.Error())
+			userConfig.Unset(key.Name)
+		} else {
+			userConfig.Set(key.Name, newValue)
+		}
+	}
+
+	// custom channel environment variable, e.g. GNVM_CHANNEL_BETA is channel.beta
+	keys, exist := allKeys(), make(map[string]bool)
+	for _, key := range keys {
+		exist[key.Name] = true
+	}
+	for _, env := range os.Environ() {
+		name := strings.SplitN(env, "=", 2)[0]
+		if !strings.HasPrefix(name, EnvName(CHANNEL_PREFIX)) {
+			continue
+		}
+		key, err := Lookup(CHANNEL_PREFIX + strings.ToLower(strings.TrimPrefix(name, EnvName(CHANNEL_PREFIX))))
+		if err != nil {
+			P(WARING, "environment variable %v ignored, Error: %v.\n", name, err.Error())
+		} else if !exist[key.Name] {
+			keys, exist[key.Name] = append(keys, *key), true
+		}
+	}
+
+	for _, key := range keys {
+		name := EnvName(key.Name)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if newValue, err := key.Validate(value); err != nil {
+			P(WARING, "environment variable %v ignored, Error: %v.\n", name, err.Error())
+		} else {
+			envs[key.Name] = newValue
+		}
+	}
+}
+
+/*
+Return environment variable name of config property
+
+Param:
+  - key: config property, e.g. registry channel.beta
+
+Return:
+  - name: e.g. GNVM_REGISTRY GNVM_CHANNEL_BETA
+*/
+func EnvName(key string) string {
+	return ENV_PREFIX + strings.ToUpper(strings.Replace(key, ".", "_", -1))
+}
+
+/*
+Override config property by command-line flag, highest priority, not write .gnvmrc
+
+Param:
+  - key:   config property
+  - value: config property value
+
+Return:
+  - error
+*/
+func Override(key, value string) error {
+	k, err := Lookup(key)
+	if err != nil {
+		return err
+	}
+	newValue, err := k.Validate(value)
+	if err != nil {
+		return err
+	}
+	flags[key] = newValue
+	applyTransport()
+	return nil
+}
+
+/*
+Return config property effective value and source
+
+Param:
+  - key: config property
+
+Return:
+  - value:  effective value
+  - source: include: SOURCE_DEFAULT SOURCE_FILE SOURCE_USER SOURCE_ENV SOURCE_FLAG
+*/
+func Explain(key string) (string, string) {
+	if value, ok := flags[key]; ok {
+		return value, SOURCE_FLAG
+	}
+	if value, ok := envs[key]; ok {
+		return value, SOURCE_ENV
+	}
+	if value, err := userConfig.Get(key); err == nil && value != nil {
+		return fmt.Sprint(value), SOURCE_USER
+	}
+	if value, err := config.Get(key); err == nil && value != nil {
+		return fmt.Sprint(value), SOURCE_FILE
+	}
+	k, _ := Lookup(key)
+	return k.DefaultValue(), SOURCE_DEFAULT
+}
+
+/*
+Print all config property effective value and source
+*/
+func ExplainAll() {
+	for _, key := range allKeys() {
+		value, source := Explain(key.Name)
+		from := source
+		switch source {
+		case SOURCE_FILE:
+			from = configPath
+		case SOURCE_USER:
+			from = userPath
+		case SOURCE_ENV:
+			from = "environment variable " + EnvName(key.Name)
+		case SOURCE_FLAG:
+			from = "command-line flag"
+		}
+		P(DEFAULT, "gnvm config %v is %v from %v\n", key.Name, value, from)
+	}
+}
@@ -0,0 +1,275 @@
+package config
+
+import (
+	// go
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// local
+	"gnvm/console"
+	"gnvm/util"
+)
+
+/*
+Config property value kind, include:
+  - KIND_URL:      http:// or https:// url, e.g. https://nodejs.org/dist/
+  - KIND_PATH:     absolute and exist folder, e.g. x:\xxx\xxx
+  - KIND_VERSION:  Node.js version, e.g. x.xx.xx x.xx.xx-x86 unknown
+  - KIND_BOOL:     true or false
+  - KIND_DURATION: go duration, e.g. 10s 1m30s
+  - KIND_LANG:     message language, e.g. en zh-CN
+  - KIND_VERSIONS: comma separated Node.js versions, e.g. 16.20.0,18.16.0-x86
+*/
+type Kind int
+
+const (
+	KIND_URL Kind = iota
+	KIND_PATH
+	KIND_VERSION
+	KIND_BOOL
+	KIND_DURATION
+	KIND_LANG
+	KIND_VERSIONS
+)
+
+var kindNames = map[Kind]string{KIND_URL: "url", KIND_PATH: "path", KIND_VERSION: "version", KIND_BOOL: "bool", KIND_DURATION: "duration", KIND_LANG: "lang", KIND_VERSIONS: "versions"}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+/*
+Config property schema
+
+  - Name:     property name, e.g. registry
+  - Kind:     property value kind, usage Validate()
+  - Default:  property default value, when Default == "", property is optional
+  - Usage:    property description
+*/
+type Key struct {
+	Name    string
+	Kind    Kind
+	Default string
+	Usage   string
+}
+
+const (
+	PROXY    = "proxy"
+	TIMEOUT  = "timeout"
+	LANG     = "lang"
+	SCHEDULE = "schedule"
+	SILENCE  = "silence"
+
+	PROXY_VAL    = ""
+	TIMEOUT_VAL  = "10s"
+	LANG_VAL     = ""
+	SCHEDULE_VAL = util.ORIGIN_RELEASE
+	SILENCE_VAL  = ""
+)
+
+/*
+All support config property, order is .gnvmrc write order
+*/
+var Keys = []Key{
+	{REGISTRY, KIND_URL, util.ORIGIN_DEFAULT, "Node.js download registry."},
+	{NODEROOT, KIND_PATH, "", "Node.js root folder, default is gnvm.exe or node.exe folder."},
+	{GLOBAL_VERSION, KIND_VERSION, GLOBAL_VERSION_VAL, "Node.js global version."},
+	{LATEST_VERSION, KIND_VERSION, LATEST_VERSION_VAL, "Node.js latest version."},
+	{PROXY, KIND_URL, PROXY_VAL, "http and https proxy, e.g. http://127.0.0.1:1080/"},
+	{TIMEOUT, KIND_DURATION, TIMEOUT_VAL, "registry request timeout, e.g. 10s 1m"},
+	{LANG, KIND_LANG, LANG_VAL, "message language, include: en and zh-CN, default from LANG environment variable"},
+	{SCHEDULE, KIND_URL, SCHEDULE_VAL, "Node.js release schedule folder, usage <schedule>schedule.json, e.g. https://raw.githubusercontent.com/nodejs/Release/main/"},
+	{SILENCE, KIND_VERSIONS, SILENCE_VAL, "Node.js versions not warn end-of-life and security release, e.g. 16.20.0,18.16.0-x86"},
+}
+
+/*
+Typed config, usage Load() create
+*/
+type Config struct {
+	Registry      string        `json:"registry"`
+	NodeRoot      string        `json:"noderoot"`
+	GlobalVersion string        `json:"globalversion"`
+	LatestVersion string        `json:"latestversion"`
+	Proxy         string        `json:"proxy"`
+	Timeout       time.Duration `json:"timeout"`
+	Lang          string        `json:"lang"`
+	Schedule      string        `json:"schedule"`
+	Silence       []string      `json:"silence"`
+}
+
+/*
+Find config property schema by name
+
+Param:
+  - name: config property name
+
+Return:
+  - *Key
+  - error: when not found, error message include suggestions
+*/
+func Lookup(name string) (*Key, error) {
+	for i := range Keys {
+		if Keys[i].Name == name {
+			return &Keys[i], nil
+		}
+	}
+	if key := channelKey(name); key != nil {
+		return key, nil
+	}
+	msg := fmt.Sprintf("%v not a valid config keyword", name)
+	if arr := suggest(name); len(arr) > 0 {
+		msg += ", did you mean " + strings.Join(arr, " or ") + "?"
+	}
+	return nil, errors.New(msg)
+}
+
+/*
+Return default value of config property
+
+Param:
+  - key: config property schema
+
+Return:
+  - value: default value, noderoot default is util.GlobalNodePath
+*/
+func (key *Key) DefaultValue() string {
+	if key.Name == NODEROOT {
+		return util.GlobalNodePath
+	}
+	return key.Default
+}
+
+/*
+Validate and normalize config property value
+
+Param:
+  - value: config property value
+
+Return:
+  - value: normalize value, e.g. registry add suffix '/'
+  - error
+*/
+func (key *Key) Validate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if key.Default == "" && key.Name != NODEROOT {
+			return value, nil
+		}
+		return value, fmt.Errorf("%v value can't be empty", key.Name)
+	}
+	switch key.Kind {
+	case KIND_URL:
+		return validateURL(key.Name, value)
+	case KIND_PATH:
+		if !filepath.IsAbs(value) {
+			return value, fmt.Errorf("%v value %v must be absolute path", key.Name, value)
+		}
+		if !util.IsDirExist(value) {
+			return value, fmt.Errorf("%v value %v folder is not exist", key.Name, value)
+		}
+		return filepath.Clean(value), nil
+	case KIND_VERSION:
+		if !util.VerifyNodeVer(value) {
+			return value, fmt.Errorf("%v value %v not an valid Node.js version", key.Name, value)
+		}
+		return strings.ToLower(value), nil
+	case KIND_BOOL:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return value, fmt.Errorf("%v value %v must be true or false", key.Name, value)
+		}
+		return strconv.FormatBool(b), nil
+	case KIND_DURATION:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return value, fmt.Errorf("%v value %v must be positive duration, e.g. 10s 1m", key.Name, value)
+		}
+		return d.String(), nil
+	case KIND_LANG:
+		lang, err := console.ParseLang(value)
+		if err != nil {
+			return value, fmt.Errorf("%v value %v", key.Name, err.Error())
+		}
+		return lang, nil
+	case KIND_VERSIONS:
+		versions := []string{}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.ToLower(strings.TrimSpace(v)); v == "" || contains(versions, v) {
+				continue
+			}
+			if !util.VerifyNodeVer(v) {
+				return value, fmt.Errorf("%v value %v not an valid Node.js version", key.Name, v)
+			}
+			versions = append(versions, v)
+		}
+		return strings.Join(versions, ","), nil
+	}
+	return value, nil
+}
+
+func contains(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func validateURL(name, value string) (string, error) {
+	if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+		if strings.Contains(value, "://") {
+			return value, fmt.Errorf("%v value %v need https:// or http://", name, value)
+		}
+		return value, fmt.Errorf("%v value %v need https:// or http://, e.g. %v", name, value, "https://"+value)
+	}
+	if !strings.HasSuffix(value, "/") {
+		value += "/"
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return value, fmt.Errorf("%v value %v must valid url", name, value)
+	}
+	return value, nil
+}
+
+/*
+Return similar config property names, usage Levenshtein distance and prefix
+*/
+func suggest(name string) []string {
+	var arr []string
+	name = strings.ToLower(name)
+	for _, key := range Keys {
+		if name != "" && (strings.HasPrefix(key.Name, name) || distance(name, key.Name) <= 2) {
+			arr = append(arr, key.Name)
+		}
+	}
+	sort.Strings(arr)
+	return arr
+}
+
+func distance(s, t string) int {
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr := make([]int, len(t)+1)
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(t)]
+}
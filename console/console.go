@@ -0,0 +1,231 @@
+/*
+Console output of gnvm, the same usage as github.com/Kenshin/cprint, include:
+  - level:  quiet, normal, verbose and debug
+  - color:  disable by --no-color, NO_COLOR environment variable or output not a terminal
+  - stream: results print to stdout, errors and diagnostics print to stderr
+*/
+package console
+
+import (
+	// lib
+	"github.com/daviddengcn/go-colortext"
+
+	// go
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+/*
+Print flag, include:
+  - DEFAULT: result, print to stdout
+  - NOTICE:  progress, print to stdout, hide when quiet
+  - WARING:  warning, print to stderr, hide when quiet
+  - ERROR:   error, print to stderr
+  - VERBOSE: e.g. resolved paths, print to stderr when verbose
+  - DEBUG:   e.g. http requests, print to stderr when debug
+*/
+const (
+	DEFAULT = ""
+	WARING  = "Waring"
+	ERROR   = "Error"
+	NOTICE  = "Notice"
+	VERBOSE = "Verbose"
+	DEBUG   = "Debug"
+)
+
+// Parse identifying
+const SPLIT = "%v"
+
+/*
+Color, include: None Black Red Green Yellow Blue Magenta Cyan White
+*/
+const (
+	None = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+)
+
+/*
+Output level, include:
+  - LEVEL_QUIET:   only results and errors
+  - LEVEL_NORMAL:  default, include notices and warnings
+  - LEVEL_VERBOSE: include VERBOSE messages
+  - LEVEL_DEBUG:   include DEBUG messages
+*/
+const (
+	LEVEL_QUIET = iota
+	LEVEL_NORMAL
+	LEVEL_VERBOSE
+	LEVEL_DEBUG
+)
+
+/*
+Color Print
+  - FgColor : Foreground color
+  - FgBright: Foreground color is it bright?
+  - BgColor : Background color
+  - BgBright: Background color is it bright?
+  - Value   : Color Message
+*/
+type CP struct {
+	FgColor  int
+	FgBright bool
+	BgColor  int
+	BgBright bool
+	Value    string
+}
+
+var (
+	Level            = LEVEL_NORMAL
+	Color            = true
+	Stdout io.Writer = os.Stdout
+	Stderr io.Writer = os.Stderr
+)
+
+/*
+Set output level and color
+
+Param:
+  - level:   e.g. LEVEL_QUIET
+  - noColor: when true or NO_COLOR environment variable not empty, disable color
+*/
+func Configure(level int, noColor bool) {
+	Level = level
+	Color = !noColor && os.Getenv("NO_COLOR") == ""
+}
+
+/*
+Print coloured and translated message, when args last value is "\n", auto new line.
+
+Param:
+  - flag:    include: DEFAULT NOTICE WARING ERROR VERBOSE DEBUG
+  - message: print content, placeholder is %v
+  - args:    string or CP
+
+e.g.
+
+	P(WARING, "Remote latest version %v = latest version %v.\n", param1, param2)
+	P(DEFAULT, "Current version %v, publish data: ", CP{Red, false, None, false, "5.10.1"}, "2014-05-31")
+*/
+func P(flag string, message interface{}, args ...interface{}) {
+	if !Enabled(flag) {
+		return
+	}
+	w := writer(flag)
+	state(w, flag)
+	for k, v := range strings.Split(T(fmt.Sprint(message)), SPLIT) {
+		fmt.Fprint(w, v)
+		if k < len(args) {
+			if cp, ok := args[k].(CP); ok {
+				custom(w, cp)
+			} else {
+				paint(w, ct.Green, true, ct.None, false, fmt.Sprint(args[k]))
+			}
+		}
+	}
+}
+
+/*
+Print error message to stderr, usage recover
+
+Param:
+  - flag:    include: DEFAULT NOTICE WARING ERROR
+  - message: print content
+  - err:     error content
+*/
+func Error(flag, message string, err interface{}) {
+	state(Stderr, flag)
+	paint(Stderr, ct.Red, false, ct.Green, false, T(message)+fmt.Sprint(err))
+	fmt.Fprintln(Stderr)
+}
+
+/*
+Print VERBOSE message, e.g. resolved paths
+*/
+func Verbose(message string, args ...interface{}) {
+	P(VERBOSE, message, args...)
+}
+
+/*
+Print DEBUG message, e.g. http requests
+*/
+func Debug(message string, args ...interface{}) {
+	P(DEBUG, message, args...)
+}
+
+/*
+Return true when flag print in current level
+*/
+func Enabled(flag string) bool {
+	switch flag {
+	case NOTICE, WARING:
+		return Level >= LEVEL_NORMAL
+	case VERBOSE:
+		return Level >= LEVEL_VERBOSE
+	case DEBUG:
+		return Level >= LEVEL_DEBUG
+	}
+	return true
+}
+
+func writer(flag string) io.Writer {
+	switch flag {
+	case DEFAULT, NOTICE:
+		return Stdout
+	}
+	return Stderr
+}
+
+func state(w io.Writer, flag string) {
+	switch flag {
+	case NOTICE:
+		paint(w, ct.Blue, false, ct.White, false, T("Notice: "))
+	case WARING:
+		paint(w, ct.Green, false, ct.Red, false, T("Waring: "))
+	case ERROR:
+		paint(w, ct.Red, false, ct.Green, false, T("Error: "))
+	case VERBOSE:
+		paint(w, ct.Cyan, false, ct.None, false, "Verbose: ")
+	case DEBUG:
+		paint(w, ct.Magenta, false, ct.None, false, "Debug: ")
+	}
+}
+
+func custom(w io.Writer, cp CP) {
+	if cp.FgColor > White || cp.FgColor < None || cp.BgColor > White || cp.BgColor < None {
+		paint(w, ct.Green, true, ct.None, false, cp.Value)
+		return
+	}
+	paint(w, ct.Color(cp.FgColor), cp.FgBright, ct.Color(cp.BgColor), cp.BgBright, cp.Value)
+}
+
+func paint(w io.Writer, fg ct.Color, fgBright bool, bg ct.Color, bgBright bool, msg string) {
+	if !Color || !isTerminal(w) {
+		fmt.Fprint(w, msg)
+		return
+	}
+	ct.Writer = w
+	ct.ChangeColor(fg, fgBright, bg, bgBright)
+	fmt.Fprint(w, msg)
+	ct.ResetColor()
+}
+
+/*
+Return true when writer is a terminal( character device ), piped output not print color
+*/
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,74 @@
+package console
+
+import (
+	// go
+	"bytes"
+	"testing"
+)
+
+func capture(t *testing.T, level int) (*bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	out, err, lv := Stdout, Stderr, Level
+	t.Cleanup(func() { Stdout, Stderr, Level = out, err, lv })
+	Stdout, Stderr, Level = stdout, stderr, level
+	return stdout, stderr
+}
+
+func TestP(t *testing.T) {
+	stdout, stderr := capture(t, LEVEL_NORMAL)
+
+	P(DEFAULT, "v%v -- %v\n", "5.10.1", CP{Red, false, None, false, "global"})
+	P(NOTICE, "root is %v\n", "C:\\nodejs")
+	P(WARING, "%v folder exist.\n", "5.10.1")
+	P(ERROR, "%v not an valid Node.js version.\n", "5.10")
+	P(DEFAULT, "limit %v\n", 10)
+	Verbose("registry is %v\n", "https://nodejs.org/dist/")
+
+	if want := "v5.10.1 -- global\nNotice: root is C:\\nodejs\nlimit 10\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "Waring: 5.10.1 folder exist.\nError: 5.10 not an valid Node.js version.\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		level          int
+		stdout, stderr string
+	}{
+		{LEVEL_QUIET, "result\n", "Error: error\n"},
+		{LEVEL_NORMAL, "result\nNotice: notice\n", "Waring: waring\nError: error\n"},
+		{LEVEL_VERBOSE, "result\nNotice: notice\n", "Waring: waring\nError: error\nVerbose: verbose\n"},
+		{LEVEL_DEBUG, "result\nNotice: notice\n", "Waring: waring\nError: error\nVerbose: verbose\nDebug: debug\n"},
+	}
+	for _, test := range tests {
+		stdout, stderr := capture(t, test.level)
+		P(DEFAULT, "result\n")
+		P(NOTICE, "notice\n")
+		P(WARING, "waring\n")
+		P(ERROR, "error\n")
+		Verbose("verbose\n")
+		Debug("debug\n")
+		if stdout.String() != test.stdout || stderr.String() != test.stderr {
+			t.Errorf("level %v stdout = %q, stderr = %q", test.level, stdout.String(), stderr.String())
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	lv, color := Level, Color
+	defer func() { Level, Color = lv, color }()
+
+	t.Setenv("NO_COLOR", "")
+	if Configure(LEVEL_DEBUG, false); Level != LEVEL_DEBUG || !Color {
+		t.Fatalf("Configure(debug, false) level %v, color %v", Level, Color)
+	}
+	if Configure(LEVEL_NORMAL, true); Color {
+		t.Fatal("Configure(normal, true) color is true")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if Configure(LEVEL_NORMAL, false); Color {
+		t.Fatal("NO_COLOR=1 color is true")
+	}
+}
@@ -0,0 +1,112 @@
+package console
+
+import (
+	// go
+	"fmt"
+	"os"
+	"strings"
+)
+
+/*
+Message language, include:
+  - LANG_EN:    English, default, message catalog key
+  - LANG_ZH_CN: Simplified Chinese
+*/
+const (
+	LANG_EN    = "en"
+	LANG_ZH_CN = "zh-CN"
+)
+
+var Lang = LANG_EN
+
+/*
+Message catalog, key is English message, value is translated message with the same %v order
+*/
+var catalogs = map[string]map[string]string{
+	LANG_ZH_CN: zhCN,
+}
+
+/*
+Normalize language
+
+Param:
+  - lang: e.g. zh_CN.UTF-8 zh-cn zh en_US.UTF-8 C
+
+Return:
+  - lang:  include: LANG_EN LANG_ZH_CN
+  - error: not support language
+*/
+func ParseLang(lang string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(lang))
+	s = strings.Replace(strings.SplitN(strings.SplitN(s, ".", 2)[0], "@", 2)[0], "_", "-", -1)
+	switch {
+	case s == "zh" || s == "zh-cn" || s == "zh-sg" || s == "zh-hans" || strings.HasPrefix(s, "zh-hans-"):
+		return LANG_ZH_CN, nil
+	case s == "en" || strings.HasPrefix(s, "en-") || s == "c" || s == "posix":
+		return LANG_EN, nil
+	}
+	return LANG_EN, fmt.Errorf("%v not a supported language, include: %v and %v", lang, LANG_EN, LANG_ZH_CN)
+}
+
+/*
+Detect language from LC_ALL, LC_MESSAGES and LANG environment variables, default LANG_EN
+*/
+func DetectLang() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			lang, _ := ParseLang(value)
+			return lang
+		}
+	}
+	return LANG_EN
+}
+
+/*
+Set message language
+
+Param:
+  - lang: see ParseLang, when "", usage DetectLang
+
+Return:
+  - error: not support language, usage LANG_EN
+*/
+func SetLang(lang string) error {
+	if lang == "" {
+		Lang = DetectLang()
+		return nil
+	}
+	var err error
+	Lang, err = ParseLang(lang)
+	return err
+}
+
+/*
+Translate message by current language, when not found, return message
+
+Param:
+  - message: English message, e.g. "%v folder exist.\n"
+*/
+func T(message string) string {
+	if msg, ok := catalogs[Lang][message]; ok {
+		return msg
+	}
+	return message
+}
+
+/*
+Translate multi-line help text, each line format is "<usage> :<description>" or description
+
+Param:
+  - text: e.g. cobra.Command Long
+*/
+func TLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if arr := strings.SplitN(line, " :", 2); len(arr) == 2 && arr[1] != "" {
+			lines[i] = arr[0] + " :" + T(arr[1])
+		} else {
+			lines[i] = T(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
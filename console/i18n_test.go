@@ -0,0 +1,77 @@
+package console
+
+import (
+	// go
+	"strings"
+	"testing"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		lang, want string
+		ok         bool
+	}{
+		{"zh_CN.UTF-8", LANG_ZH_CN, true},
+		{"zh-cn", LANG_ZH_CN, true},
+		{"zh", LANG_ZH_CN, true},
+		{"zh-Hans-CN", LANG_ZH_CN, true},
+		{"en_US.UTF-8", LANG_EN, true},
+		{"C", LANG_EN, true},
+		{"fr_FR.UTF-8", LANG_EN, false},
+	}
+	for _, test := range tests {
+		if got, err := ParseLang(test.lang); got != test.want || (err == nil) != test.ok {
+			t.Errorf("ParseLang(%v) = %v, %v, want %v", test.lang, got, err, test.want)
+		}
+	}
+}
+
+func TestDetectLang(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	if lang := DetectLang(); lang != LANG_ZH_CN {
+		t.Fatalf("DetectLang() = %v", lang)
+	}
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	if lang := DetectLang(); lang != LANG_EN {
+		t.Fatalf("DetectLang() LC_ALL = %v", lang)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, value := range catalog {
+			if strings.Count(key, SPLIT) != strings.Count(value, SPLIT) || strings.HasSuffix(key, "\n") != strings.HasSuffix(value, "\n") {
+				t.Errorf("%v catalog %q = %q, placeholder or new line not match", lang, key, value)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLang(LANG_EN)
+	stdout, stderr := capture(t, LEVEL_NORMAL)
+
+	if err := SetLang("zh_CN"); err != nil {
+		t.Fatal(err)
+	}
+	P(DEFAULT, "%v folder exist.\n", "5.10.1")
+	P(ERROR, "%v. See '%v'.\n", "abc", "gnvm help")
+	if want := "5.10.1 文件夹已存在。\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "错误： abc 。参见 'gnvm help' 。\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+	if got, want := TLines("Exit code:\n  0 :success.\nnot translate"), "退出码：\n  0 :成功。\nnot translate"; got != want {
+		t.Errorf("TLines() = %q, want %q", got, want)
+	}
+
+	if err := SetLang("xx"); err == nil || Lang != LANG_EN {
+		t.Fatalf("SetLang(xx) = %v, lang %v", err, Lang)
+	}
+	if msg := T("%v folder exist.\n"); msg != "%v folder exist.\n" {
+		t.Errorf("T() en = %q", msg)
+	}
+}
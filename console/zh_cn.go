@@ -0,0 +1,527 @@
+package console
+
+/*
+Simplified Chinese message catalog, include:
+  - print flag
+  - P() messages of command, config, nodehandle and util
+  - cobra help text, include Short, Long description and flag usage
+*/
+var zhCN = map[string]string{
+	// print flag
+	"Notice: ": "提示： ",
+	"Waring: ": "警告： ",
+	"Error: ":  "错误： ",
+
+	// command
+	"'%v' no parameter, please check your input. See '%v'.\n":                                                      "'%v' 不需要参数，请检查输入。参见 '%v' 。\n",
+	"'%v' need parameter, please check your input. See '%v'.\n":                                                    "'%v' 需要参数，请检查输入。参见 '%v' 。\n",
+	"when use %v must be only one parameter, e.g. '%v'. See '%v'.\n":                                               "使用 %v 时只能有一个参数，例如 '%v' 。参见 '%v' 。\n",
+	"%v need parameter, please check your input. See '%v'.\n":                                                      "%v 需要参数，请检查输入。参见 '%v' 。\n",
+	"remove all folder Error: %v\n":                                                                                "删除全部文件夹失败，错误： %v\n",
+	"'%v' not supported mixed parameters, please usage '%v'. See '%v'.\n":                                          "'%v' 不支持混合参数，请使用 '%v' 。参见 '%v' 。\n",
+	"%v must be only %v parameter, please check your input. See '%v'.\n":                                           "%v 只能有 %v 个参数，请检查输入。参见 '%v' 。\n",
+	"%v need parameter and only one parameter, support [%v] or [%v] keyword, please check your input. See '%v'.\n": "%v 需要且只能有一个参数，支持 [%v] 或 [%v] 关键字，请检查输入。参见 '%v' 。\n",
+	"%v only support [%v] or [%v] parameter. See '%v'.\n":                                                          "%v 只支持 [%v] 或 [%v] 参数。参见 '%v' 。\n",
+	"%v must be one parameter and only support [%v] keyword, please check your input. See '%v'.\n":                 "%v 只能有一个参数且只支持 [%v] 关键字，请检查输入。参见 '%v' 。\n",
+	"%v only support [%v] keyword, please check your input. See '%v'.\n":                                           "%v 只支持 [%v] 关键字，请检查输入。参见 '%v' 。\n",
+	"%v no parameter, please check your input. See '%v'.\n":                                                        "%v 不需要参数，请检查输入。参见 '%v' 。\n",
+	"%v no support flag %v, please check your input. See '%v'.\n":                                                  "%v 不支持 %v 参数，请检查输入。参见 '%v' 。\n",
+	"%v must be positive integer, please check your input. See '%v'.\n":                                            "%v 必须为正整数，请检查输入。参见 '%v' 。\n",
+	"flag %v depends on %v flag, e.g. '%v', See '%v'.\n":                                                           "%v 参数依赖 %v 参数，例如 '%v' 。参见 '%v' 。\n",
+	"%v parameter only support [%v] or [%v] keyword, please check your input. See '%v'.\n":                         "%v 参数只支持 [%v] 或 [%v] 关键字，请检查输入。参见 '%v' 。\n",
+	"%v must be two parameter, e.g. '%v'. See '%v'.\n":                                                             "%v 必须有两个参数，例如 '%v' 。参见 '%v' 。\n",
+	"%v must be one parameter, e.g. '%v'. See '%v'.\n":                                                             "%v 必须有一个参数，例如 '%v' 。参见 '%v' 。\n",
+	"Unset success, %v restore default value %v\n":                                                                 "删除成功， %v 恢复为默认值 %v\n",
+	"gnvm config %v is %v\n":                                                                                       "gnvm config %v 为 %v\n",
+	"Set success, %v new value is %v\n":                                                                            "设置成功， %v 的新值为 %v\n",
+	"%v parameter maximum is 2, please check your input. See '%v'.\n":                                              "%v 最多只能有 2 个参数，请检查输入。参见 '%v' 。\n",
+	"flag %v can not be used with %v or %v. See '%v'.\n":                                                           "%v 参数不能与 %v 或 %v 同时使用。参见 '%v' 。\n",
+	"flag %v can not be used with %v. See '%v'.\n":                                                                 "%v 参数不能与 %v 同时使用。参见 '%v' 。\n",
+	"%v must be one parameter, please check your input. See '%v'.\n":                                               "%v 必须有一个参数，请检查输入。参见 '%v' 。\n",
+	"%v must be one parameter and only support [%v] [%v] [%v] keyword, please check your input. See '%v'.\n":       "%v 只能有一个参数且只支持 [%v] [%v] [%v] 关键字，请检查输入。参见 '%v' 。\n",
+	"%v value %v, Error: %v. See '%v'.\n":                                                                          "%v 的值 %v 错误： %v 。参见 '%v' 。\n",
+	"%v. See '%v'.\n":                                                                                              "%v 。参见 '%v' 。\n",
+	"%v format error, must be '%v'. See '%v'.\n":                                                                   "%v 格式错误，必须为 '%v' 。参见 '%v' 。\n",
+
+	// config
+	"config file create Error: %v\n":                                                            "创建配置文件失败，错误： %v\n",
+	"not found %v node.exe, please use '%v'. See '%v'.\n":                                       "未找到 %v node.exe ，请使用 '%v' 。参见 '%v' 。\n",
+	"write config file Error: %v\n":                                                             "写入配置文件失败，错误： %v\n",
+	"Config file %v create success.\n":                                                          "配置文件 %v 创建成功。\n",
+	"read config file fail, please use '%v'. \nError: %v\n":                                     "读取配置文件失败，请使用 '%v' 。\n错误： %v\n",
+	"%v is overridden by %v, effective value is %v. See '%v'.\n":                                "%v 被 %v 覆盖，生效值为 %v 。参见 '%v' 。\n",
+	"remove config file Error: %v\n":                                                            "删除配置文件失败，错误： %v\n",
+	"%v      init success, new value is %v\n":                                                   "%v      初始化成功，新值为 %v\n",
+	"%v init success, new value is %v\n":                                                        "%v 初始化成功，新值为 %v\n",
+	"config file path %v \n":                                                                    "配置文件路径 %v \n",
+	"gnvm config registry %v vaild %v, Error: %v.":                                              "gnvm config registry %v 验证 %v ，错误： %v 。",
+	"%v, response code: %v.\n":                                                                  "%v ，响应码： %v 。\n",
+	"gnvm config registry %v valid ":                                                            "gnvm config registry %v 验证 ",
+	"gnvm.exe an error has occurred. please check. \nError: ":                                   "gnvm.exe 发生错误，请检查。\n错误： ",
+	"config file %v is not exist.\n":                                                            "配置文件 %v 不存在。\n",
+	"gnvm config %v is %v from %v\n":                                                            "gnvm config %v 为 %v ，来自 %v\n",
+	"read user config file %v fail, Error: %v\n":                                                "读取用户配置文件 %v 失败，错误： %v\n",
+	"environment variable %v ignored, Error: %v.\n":                                             "已忽略环境变量 %v ，错误： %v 。\n",
+	"current latest version is %v.\n":                                                           "当前 latest 版本为 %v 。\n",
+	"gnvm root is resolved by %v, not create config file, please set %v or use %v. See '%v'.\n": "gnvm 根目录由 %v 推断，不创建配置文件，请设置 %v 或使用 %v 。参见 '%v' 。\n",
+
+	// nodehandle
+	"'gnvm doctor' an error has occurred. please check. \nError: ": "'gnvm doctor' 发生错误，请检查。\n错误： ",
+	"       fix: %v\n": "       修复： %v\n",
+	"current Node.js version is %v, not re-use. See '%v'.\n":                                         "当前 Node.js 版本已是 %v ，无需重复切换。参见 '%v' 。\n",
+	"Set success, global Node.js version is %v.\n":                                                   "设置成功，全局 Node.js 版本为 %v 。\n",
+	"'%v' command is no longer supported. See '%v'.\n":                                               "'%v' 命令已不再支持。参见 '%v' 。\n",
+	"current operating system is %v, not support %v suffix.\n":                                       "当前操作系统为 %v ，不支持 %v 后缀。\n",
+	"local  latest version is %v.\n":                                                                 "本地 latest 版本为 %v 。\n",
+	"get latest version error, please check. See '%v'.\n":                                            "获取 latest 版本失败，请检查。参见 '%v' 。\n",
+	"remote latest version is %v.\n":                                                                 "远程 latest 版本为 %v 。\n",
+	"remote %v latest version is %v.\n":                                                              "远程 %v 渠道 latest 版本为 %v 。\n",
+	"Start install Node.js build from %v, please wait.\n":                                            "开始从 %v 安装 Node.js 构建，请稍等。\n",
+	"Node.js build %v install success, node.exe version is %v, arch is %v.\n":                        "Node.js 构建 %v 安装成功， node.exe 版本为 %v ，架构为 %v 。\n",
+	"get %v latest version error, please check. See '%v'.\n":                                         "获取 %v 渠道 latest 版本失败，请检查。参见 '%v' 。\n",
+	"%v folder exist.\n":                                                                             "%v 文件夹已存在。\n",
+	"Start download Node.js versions [%v].\n":                                                        "开始下载 Node.js 版本 [%v] 。\n",
+	"current latest version is %v, please usage '%v' first. See '%v'.\n":                             "当前 latest 版本为 %v ，请先使用 '%v' 。参见 '%v' 。\n",
+	"Node.js version %v uninstall success.\n":                                                        "Node.js 版本 %v 卸载成功。\n",
+	"local  Node.js latest version is %v.\n":                                                         "本地 Node.js latest 版本为 %v 。\n",
+	"remote Node.js latest version is %v from %v.\n":                                                 "远程 Node.js latest 版本为 %v ，来自 %v 。\n",
+	"Update Node.js latest success, current latest version is %v.\n":                                 "更新 Node.js latest 成功，当前 latest 版本为 %v 。\n",
+	"Remote latest version %v %v latest version %v, don't need to upgrade.\n":                        "远程 latest 版本 %v %v 本地 latest 版本 %v ，无需升级。\n",
+	"%v folder is not exist. See '%v'.\n":                                                            "%v 文件夹不存在。参见 '%v' 。\n",
+	"Local Node.js latest version is %v.\n":                                                          "本地 Node.js latest 版本为 %v 。\n",
+	"local latest version %v %v remote latest version %v.\nPlease check your config %v. See '%v'.\n": "本地 latest 版本 %v %v 远程 latest 版本 %v 。\n请检查配置 %v 。参见 '%v' 。\n",
+	"remote latest version %v %v local latest version %v.\n":                                         "远程 latest 版本 %v %v 本地 latest 版本 %v 。\n",
+	"Update success, Node.js latest version is %v.\n":                                                "更新成功， Node.js latest 版本为 %v 。\n",
+	"Search Node.js version rules [%v] from %v, please wait.\n":                                      "正在按规则 [%v] 查询 Node.js 版本，来自 %v ，请稍候。\n",
+	"'%v' get url %v error, Error: %v\n":                                                             "'%v' 获取 %v 失败，错误： %v\n",
+	"gnvm root is %v, resolve by %v.\n":                                                              "gnvm 根目录为 %v ，由 %v 推断。\n",
+	"%v an error has occurred. please check. Error: %v\n":                                            "%v 发生错误，请检查。错误： %v\n",
+	"not search any Node.js version details, use rules [%v] from %v.\n":                              "未查询到任何 Node.js 版本，规则为 [%v] ，来自 %v 。\n",
+	"registry is %v.\n": "registry 为 %v 。\n",
+	"'gnvm ls' an error has occurred. please check. \nError: ": "'gnvm ls' 发生错误，请检查。\n错误： ",
+	"'%v' Error: %v.\n":      "'%v' 错误： %v 。\n",
+	"gnvm.exe root is %v \n": "gnvm.exe 根目录为 %v \n",
+	"don't have any available Node.js version, please check your input. See '%v'.\n":                         "没有任何可用的 Node.js 版本，请检查输入。参见 '%v' 。\n",
+	"Read all Node.js version list from %v, please wait.\n":                                                  "正在从 %v 读取全部 Node.js 版本列表，请稍候。\n",
+	"%v an error has occurred. please check your input. Error: %v\n":                                         "%v 发生错误，请检查输入。错误： %v\n",
+	"global Node.js version is %v.\n":                                                                        "全局 Node.js 版本为 %v 。\n",
+	"Set success, %v new value is %v.\n":                                                                     "设置成功， %v 的新值为 %v 。\n",
+	"global Node.js version is %v, please use %v or %v. See '%v'.\n":                                         "全局 Node.js 版本为 %v ，请使用 %v 或 %v 。参见 '%v' 。\n",
+	"Node.js %v version is %v.\n":                                                                            "Node.js %v 版本为 %v 。\n",
+	"latest Node.js version is %v, please use %v or %v. See '%v'.\n":                                         "latest Node.js 版本为 %v ，请使用 %v 或 %v 。参见 '%v' 。\n",
+	"get remote %v Node.js %v error, please check your input. See '%v'.\n":                                   "获取远程 %v Node.js %v 失败，请检查输入。参见 '%v' 。\n",
+	"remote Node.js %v version is %v from %v.\n":                                                             "远程 Node.js %v 版本为 %v ，来自 %v 。\n",
+	"remote Node.js latest version %v %v local Node.js latest version %v, suggest to upgrade, usage '%v'.\n": "远程 Node.js latest 版本 %v %v 本地 Node.js latest 版本 %v ，建议升级，使用 '%v' 。\n",
+	"Current version %v %v.":                                                                                 "当前版本 %v %v 。",
+	"See %v for more information.":                                                                           "更多信息参见 %v 。",
+	"Latest version %v, publish data %v":                                                                     "最新版本 %v ，发布日期 %v",
+	"current latest is %v, please usage '%v' first. See '%v'.\n":                                             "当前 latest 为 %v ，请先使用 '%v' 。参见 '%v' 。\n",
+	"%v folder is not exist %v, use '%v' get local Node.js version list. See '%v'.\n":                        "%v 文件夹下不存在 %v ，使用 '%v' 获取本地 Node.js 版本列表。参见 '%v' 。\n",
+	"not found %v Node.js version.\n":                                                                        "未找到 %v Node.js 版本。\n",
+	"create %v foler error, Error: %v\n":                                                                     "创建 %v 文件夹失败，错误： %v\n",
+	"%v folder create success.\n":                                                                            "%v 文件夹创建成功。\n",
+	"rename fail, Error: %v\n":                                                                               "重命名失败，错误： %v\n",
+	"copy %v to %v faild, Error: %v \n":                                                                      "复制 %v 到 %v 失败，错误： %v \n",
+	"remove %v folder Error: %v.\n":                                                                          "删除 %v 文件夹失败，错误： %v 。\n",
+	"%v, '%v' param only support [%v] [%v] or %v e.g. [%v]. See '%v'.\n":                                     "%v ， '%v' 参数只支持 [%v] [%v] 或 %v ，例如 [%v] 。参见 '%v' 。\n",
+	"local    npm version is %v\n":                                                                           "本地     npm 版本为 %v\n",
+	"remote   npm version is %v\n":                                                                           "远程     npm 版本为 %v\n",
+	"download %v version [Y/n]? ":                                                                            "是否下载 %v 版本 [Y/n] ？ ",
+	"stdin is not interactive, please use '%v' or '%v' to answer the prompt.\n":                              "标准输入不是交互式终端，请使用 '%v' 或 '%v' 回答提示。\n",
+	"operation has been cancelled.":                                                                          "操作已取消。",
+	"Npm uninstall fail, Error: %v.\n":                                                                       "npm 卸载失败，错误： %v 。\n",
+	"Npm uninstall %v.\n":                                                                                    "npm 卸载 %v 。\n",
+	"current path %v not exist npm.\n":                                                                       "当前路径 %v 下不存在 npm 。\n",
+	"Start download new npm version %v\n":                                                                    "开始下载新的 npm 版本 %v\n",
+	"Start untgz and install %v tgz file, please wait.\n":                                                    "开始解压并安装 %v tgz 文件，请稍候。\n",
+	"Start unzip and install %v zip file, please wait.\n":                                                    "开始解压并安装 %v zip 文件，请稍候。\n",
+	"Set success, current npm version is %v.\n":                                                              "设置成功，当前 npm 版本为 %v 。\n",
+	"get %v error, usage default download url. Error: %v\n":                                                  "获取 %v 错误，使用默认下载地址。错误： %v\n",
+	"not found environment variable '%v', please use '%v'. See '%v'.\n":                                      "未找到环境变量 '%v' ，请使用 '%v' 。参见 '%v' 。\n",
+	"this command is %v, need %v permission, please note!\n":                                                 "此命令为 %v ，需要 %v 权限，请注意！\n",
+	"current environment variable %v is %v\n":                                                                "当前环境变量 %v 为 %v\n",
+	"current config %v is %v\n":                                                                              "当前配置 %v 为 %v\n",
+	"set environment variable %v is %v [Y/n]? ":                                                              "是否设置环境变量 %v 为 %v [Y/n] ？ ",
+	"add environment variable %v to %v [Y/n]? ":                                                              "是否添加环境变量 %v 到 %v [Y/n] ？ ",
+	"add environment variable %v failed. Error: %v":                                                          "添加环境变量 %v 失败。错误： %v",
+	"search environment variable %v failed. Error: %v":                                                       "查询环境变量 %v 失败。错误： %v",
+	"not found %v node.exe, not use %v. please use '%v'. See '%v'.\n":                                        "未找到 %v node.exe ，无法使用 %v 。请使用 '%v' 。参见 '%v' 。\n",
+	"sesson environment %v, path is %v.\n":                                                                   "session 环境 %v ，路径为 %v 。\n",
+	"please use '%v'. See '%v' or '%v'.\n":                                                                   "请使用 '%v' 。参见 '%v' 或 '%v' 。\n",
+	"sesson environment %v.\n":                                                                               "session 环境 %v 。\n",
+	"initialize gnvm.exe an error has occurred. please check. \nError: ":                                     "初始化 gnvm.exe 发生错误，请检查。\n错误： ",
+
+	// util
+	"%v parameter not support suffix.\n":                      "%v 参数不支持后缀。\n",
+	"%v Error: %v\n":                                          "%v 错误： %v\n",
+	"downlaod Node.js version %v, not %v. See '%v'.\n":        "下载 Node.js 版本 %v ，不存在 %v 。参见 '%v' 。\n",
+	"downlaod Node.js version %v, not %v node.exe.\n":         "下载 Node.js 版本 %v ，不存在 %v node.exe 。\n",
+	"current is %v, if you usage %v %v, you need %v first.\n": "当前为 %v ，如需使用 %v %v ，请先使用 %v 。\n",
+	"current value is %v, please use %v.\n":                   "当前值为 %v ，请使用 %v 。\n",
+
+	// util.VersionError
+	"not node.exe download":                                          "没有可下载的 node.exe",
+	"format error, suffix only must be 'x86' or 'x64'":               "格式错误，后缀只能为 'x86' 或 'x64'",
+	"format error, parameter must be 'x.xx.xx' or 'x.xx.xx-x86|x64'": "格式错误，参数必须为 'x.xx.xx' 或 'x.xx.xx-x86|x64'",
+	"not an valid Node.js version":                                   "不是有效的 Node.js 版本",
+	"not an Node.js version, npm usage 'gnvm npm'":                   "不是 Node.js 版本， npm 请使用 'gnvm npm'",
+
+	// help template
+	"help for %v":             "%v 的帮助",
+	"Usage:":                  "用法：",
+	"Aliases:":                "别名：",
+	"Examples:":               "示例：",
+	"Available Commands:":     "可用命令：",
+	"Additional Commands:":    "其它命令：",
+	"Flags:":                  "参数：",
+	"Global Flags:":           "全局参数：",
+	"Additional help topics:": "更多帮助主题：",
+	"Use \"{{.CommandPath}} [command] --help\" for more information about a command.": "使用 \"{{.CommandPath}} [command] --help\" 查看命令的更多信息。",
+	"Help about any command": "查看任意命令的帮助",
+	"Generate the autocompletion script for the specified shell": "生成指定 shell 的自动补全脚本",
+
+	// help gnvm
+	"GNVM is simple Node.js version manager on Windows by GO.":                         "GNVM 是使用 Go 编写的 Windows 下的 Node.js 多版本管理器。",
+	"GNVM is simple Node.js version manager on Windows by GO. e.g. nvm, nvmw, nodist.": "GNVM 是使用 Go 编写的 Windows 下的 Node.js 多版本管理器，类似 nvm 、 nvmw 、 nodist 。",
+	"See https://github.com/kenshin/gnvm for more information.":                        "更多信息参见 https://github.com/kenshin/gnvm 。",
+	"Exit code:":     "退出码：",
+	"success.":       "成功。",
+	"unknown error.": "未知错误。",
+	"usage error, e.g. invalid parameter or flag.":                                 "参数错误，例如无效的参数或 flag 。",
+	"network error, e.g. registry unreachable or download fail.":                   "网络错误，例如 registry 无法访问或下载失败。",
+	"Node.js version or npm not installed.":                                        "Node.js 版本或 npm 未安装。",
+	"checksum mismatch or download size error.":                                    "下载文件校验失败。",
+	".gnvmrc read or write error.":                                                 ".gnvmrc 读写错误。",
+	"command not support in session environment.":                                  "session 环境下不支持该命令。",
+	"print result as json, include: ls, search, node-version, config and version.": "以 json 格式输出结果，支持： ls 、 search 、 node-version 、 config 和 version 。",
+	"print result format, include: text, json and tsv.":                            "输出格式，包括： text 、 json 和 tsv 。",
+	"gnvm root path, priority is higher than GNVM_HOME environment variable.":      "gnvm 根目录，优先级高于环境变量 GNVM_HOME 。",
+	"override config registry, not write .gnvmrc.":                                 "覆盖配置 registry ，不写入 .gnvmrc 。",
+	"override config property, e.g. --config timeout=30s, not write .gnvmrc.":      "覆盖配置属性，例如 --config timeout=30s ，不写入 .gnvmrc 。",
+	"only print results and errors.":                                               "只输出结果与错误。",
+	"print resolved paths and registry.":                                           "输出根目录、 registry 等解析结果。",
+	"print verbose messages and http requests.":                                    "输出详细信息与 http 请求。",
+	"disable color, the same as NO_COLOR environment variable.":                    "关闭颜色，与环境变量 NO_COLOR 相同。",
+	"answer yes to all prompts, e.g. gnvm npm and gnvm reg.":                       "所有提示均回答 yes ，例如 gnvm npm 与 gnvm reg 。",
+	"answer no to all prompts, e.g. gnvm npm and gnvm reg.":                        "所有提示均回答 no ，例如 gnvm npm 与 gnvm reg 。",
+
+	// help version
+	"Print GNVM version number":              "输出 GNVM 版本号",
+	"Print GNVM version number e.g. :":       "输出 GNVM 版本号，例如：",
+	"Print local  gnvm version information.": "输出本地 gnvm 版本信息。",
+	"Print remote gnvm latest version.":      "输出远程 gnvm 最新版本。",
+	"Print remote CHANGELOG.":                "输出远程 CHANGELOG 。",
+	"get remote gnvm latest version.":        "获取远程 gnvm 最新版本。",
+	"get remote CHANGELOG.":                  "获取远程 CHANGELOG 。",
+
+	// help install
+	"Install any Node.js version":                                                             "安装任意 Node.js 版本",
+	"Install any Node.js version e.g.":                                                        "安装任意 Node.js 版本，例如：",
+	"Download latest Node.js version from .gnvmrc registry.":                                  "从 .gnvmrc registry 下载 latest Node.js 版本。",
+	"Multiple Node.js version download.":                                                      "同时下载多个 Node.js 版本。",
+	"Assign arch  version, suffix include: x86 and x64.":                                      "指定 32 / 64 位版本，后缀包括： x86 和 x64 。",
+	"Assign io.js version.":                                                                   "指定 io.js 版本。",
+	"Download latest nightly version, channel include: rc, nightly, test and custom channel.": "下载 nightly 渠道的 latest 版本，渠道包括： rc 、 nightly 、 test 与自定义渠道。",
+	"Download rc channel version to rc@xx.x.x-rc.x folder.":                                   "下载 rc 渠道版本到 rc@xx.x.x-rc.x 文件夹。",
+	"Install local Node.js archive to x.xx.xx folder, include: .zip and node.exe.":            "安装本地 Node.js 压缩包到 x.xx.xx 文件夹，包括： .zip 与 node.exe 。",
+	"Download custom Node.js build and install to x.xx.xx-<tag> folder.":                      "下载自定义 Node.js 构建并安装到 x.xx.xx-<tag> 文件夹。",
+	"Download and auto invoke 'gnvm use x.xx.xx'.":                                            "下载并自动执行 'gnvm use x.xx.xx' 。",
+	"Not logger support command, please usage 'gnvm npm x.xx.xx'. See 'gnvm help npm'.":       "已不再支持，请使用 'gnvm npm x.xx.xx' 。参见 'gnvm help npm' 。",
+	"set this version global version.":                                                        "设置该版本为全局版本。",
+
+	// help uninstall
+	"Uninstall local Node.js version and npm":                             "卸载本地 Node.js 版本与 npm",
+	"Uninstall local Node.js version e.g.":                                "卸载本地 Node.js 版本，例如：",
+	"Uninstall npm.":                                                      "卸载 npm 。",
+	"Uninstall 0.10.28  Node.js version.":                                 "卸载 0.10.28 Node.js 版本。",
+	"Uninstall latest   Node.js version.":                                 "卸载 latest Node.js 版本。",
+	"Uninstall multiple Node.js version, e.g. 0.10.26 0.11.2-x86 latest.": "卸载多个 Node.js 版本，例如 0.10.26 0.11.2-x86 latest 。",
+	"Uninstall rc channel Node.js version, or usage rc@xx.x.x-rc.x.":      "卸载 rc 渠道 Node.js 版本，或使用 rc@xx.x.x-rc.x 。",
+	"Uninstall all      Node.js version.":                                 "卸载全部 Node.js 版本。",
+
+	// help use
+	"Use any the local already exists of Node.js version":        "使用本地已存在的任意 Node.js 版本",
+	"Use any the local already exists of Node.js version e.g.":   "使用本地已存在的任意 Node.js 版本，例如：",
+	"Usage x.xx.xx Node.js version.":                             "使用 x.xx.xx Node.js 版本。",
+	"Usage latest  Node.js version.":                             "使用 latest Node.js 版本。",
+	"Usage x.xx.xx Node.js with arch x86 version.":               "使用 x.xx.xx 32 位 Node.js 版本。",
+	"Usage rc channel Node.js version, or usage rc@xx.x.x-rc.x.": "使用 rc 渠道 Node.js 版本，或使用 rc@xx.x.x-rc.x 。",
+
+	// help session
+	"Set any local Node.js version to session Node.js version":                               "设置本地任意 Node.js 版本为 session Node.js 版本",
+	"Set any Node.js version of the local already exists to session Node.js version, e.g. :": "设置本地已存在的任意 Node.js 版本为 session Node.js 版本，例如：",
+	"Create gns.cmd.": "创建 gns.cmd 。",
+	"Remove gns.cmd.": "删除 gns.cmd 。",
+	"When session environment Start success, usage commands:": "session 环境启动成功后，可使用以下命令：",
+	"Show gns cli command help.":                              "显示 gns 命令帮助。",
+	"Set 0.10.24 is session environment.":                     "设置 0.10.24 为 session 环境。",
+	"Quit sesion Node.js, restore global Node.js version.":    "退出 session Node.js ，恢复全局 Node.js 版本。",
+	"Show gns version.":                                       "显示 gns 版本。",
+
+	// help update
+	"Update Node.js latest version":                                                                      "更新 Node.js latest 版本",
+	"Download Node.js latest version and update .gnvmrc, e.g.":                                           "下载 Node.js latest 版本并更新 .gnvmrc ，例如：",
+	"Download latest Node.js and write it(latest version) to .gnvmrc.":                                   "下载 latest Node.js 并写入 .gnvmrc 。",
+	"Download latest Node.js and write it(latest version) to .gnvmrc and auto invoke 'gnvm use latest'.": "下载 latest Node.js 并写入 .gnvmrc ，然后自动执行 'gnvm use latest' 。",
+
+	// help ls
+	"Show all [local] [remote] Node.js version":                                      "显示全部 [本地] [远程] Node.js 版本",
+	"Show all [local] [remote] Node.js version e.g.:":                                "显示全部 [本地] [远程] Node.js 版本，例如：",
+	"Print local  Node.js version list.":                                             "输出本地 Node.js 版本列表。",
+	"Print remote Node.js version list.":                                             "输出远程 Node.js 版本列表。",
+	"Print remote Node.js details version list.":                                     "输出远程 Node.js 版本详细列表。",
+	"Print remote io.js   version list.":                                             "输出远程 io.js 版本列表。",
+	"Print remote io.js   details version list.":                                     "输出远程 io.js 版本详细列表。",
+	"Print remote Node.js maximum number of rows is xx.( default, print max rows. )": "输出远程 Node.js 版本最多 xx 行。（默认输出全部）",
+	"Print local  Node.js version list as json, or usage --format=tsv.":              "以 json 格式输出本地 Node.js 版本列表，或使用 --format=tsv 。",
+	"Print remote Node.js details version list as json, include: version, date, arch, npm, lts, v8, openssl, modules, files and so on.": "以 json 格式输出远程 Node.js 版本详细列表，包括： version 、 date 、 arch 、 npm 、 lts 、 v8 、 openssl 、 modules 、 files 等。",
+	"Print remote Node.js details version list only include columns, or usage --columns=all.":                                           "输出远程 Node.js 版本详细列表，只包括指定的列，或使用 --columns=all 。",
+	"Print remote nightly Node.js version list, channel include: release, rc, nightly, test and custom channel.":                        "输出远程 nightly 渠道 Node.js 版本列表，渠道包括： release 、 rc 、 nightly 、 test 与自定义渠道。",
+	"get remote all node.js version list.":                                                                                "获取远程全部 Node.js 版本列表。",
+	"get remote all node.js version details list.":                                                                        "获取远程全部 Node.js 版本详细列表。",
+	"get remote all node.js version details list by limit count.":                                                         "按数量限制获取远程 Node.js 版本详细列表。",
+	"get remote all io.js version details list.":                                                                          "获取远程全部 io.js 版本详细列表。",
+	"print columns, include: no, date, version, exec, npm, lts, security, v8, uv, zlib, openssl, modules, files and all.": "输出的列，包括： no 、 date 、 version 、 exec 、 npm 、 lts 、 security 、 v8 、 uv 、 zlib 、 openssl 、 modules 、 files 与 all 。",
+	"get remote Node.js version list of release channel, include: release, rc, nightly, test and custom channel.":         "获取指定发布渠道的远程 Node.js 版本列表，包括： release 、 rc 、 nightly 、 test 与自定义渠道。",
+	"install Node.js from local archive or url, include: .zip and node.exe.":                                              "从本地压缩包或 url 安装 Node.js ，包括： .zip 与 node.exe 。",
+	"install --from Node.js to this folder name, e.g. 18.19.0-patched.":                                                   "将 --from 的 Node.js 安装到此文件夹名，例如 18.19.0-patched 。",
+
+	// help node-version
+	"Show [global] [latest] Node.js version":                "显示 [global] [latest] Node.js 版本",
+	"Show and fix [global] [latest] Node.js version e.g. :": "显示并修复 [global] [latest] Node.js 版本，例如：",
+	"Show Node.js global and latest version, and fix it.":   "显示并修复 Node.js global 与 latest 版本。",
+	"Show Node.js latest version, and fix it.":              "显示并修复 Node.js latest 版本。",
+	"Show Node.js global version, and fix it.":              "显示并修复 Node.js global 版本。",
+
+	// help config
+	"Setter and getter .gnvmrc file":                                                          "读取与设置 .gnvmrc 文件",
+	"Setter and getter .gnvmrc file.  e.g. :":                                                 "读取与设置 .gnvmrc 文件，例如：",
+	"Print all propertys from .gnvmrc.":                                                       "输出 .gnvmrc 全部属性。",
+	"Print all propertys from .gnvmrc as json, or usage --format=tsv.":                        "以 json 格式输出 .gnvmrc 全部属性，或使用 --format=tsv 。",
+	"Print all propertys and where each effective value came from.":                           "输出全部属性及其生效值的来源。",
+	"Initialization .gnvmrc file.":                                                            "初始化 .gnvmrc 文件。",
+	"Get .gnvmrc file props.":                                                                 "读取 .gnvmrc 文件属性。",
+	"Set .gnvmrc file props, value must be valid url, path, version, bool or duration.":       "设置 .gnvmrc 文件属性，值必须为有效的 url 、 path 、 version 、 bool 或 duration 。",
+	"Remove .gnvmrc file props, restore default value.":                                       "删除 .gnvmrc 文件属性，恢复默认值。",
+	"Custom  is valid url.":                                                                   "自定义值必须为有效的 url 。",
+	"DEFAULT is built-in variable. value is https://nodejs.org/dist/":                         "DEFAULT 为内置变量，值为 https://nodejs.org/dist/",
+	"TAOBAO  is built-in variable. value is https://cdn.npmmirror.com/binaries/node/":         "TAOBAO 为内置变量，值为 https://cdn.npmmirror.com/binaries/node/",
+	"HUAWEI  is built-in variable. value is https://mirrors.huaweicloud.com/nodejs/":          "HUAWEI 为内置变量，值为 https://mirrors.huaweicloud.com/nodejs/",
+	"Validation .gnvmfile registry property.":                                                 "验证 .gnvmrc registry 属性。",
+	"Custom  is valid http proxy url.":                                                        "自定义值必须为有效的 http 代理 url 。",
+	"Custom  is valid duration, e.g. 10s 1m.":                                                 "自定义值必须为有效的时长，例如 10s 1m 。",
+	"Custom  is message language, include: en and zh-CN.":                                     "自定义值为消息语言，包括： en 和 zh-CN 。",
+	"Custom is channel download url, e.g. gnvm config channel.beta https://example.com/beta/": "自定义值为渠道下载地址，例如 gnvm config channel.beta https://example.com/beta/",
+	"print all config property and where each effective value came from.":                     "输出全部配置属性及其生效值的来源。",
+
+	// help reg
+	"Add config property [noderoot] to Environment variable [NODE_HOME]":             "添加配置属性 [noderoot] 到环境变量 [NODE_HOME]",
+	"This is the experimental function, need Administrator permission, please note!": "此命令为实验性功能，需要管理员权限，请注意！",
+	"Add config property [noderoot] to Environment variable [NODE_HOME]. e.g. :":     "添加配置属性 [noderoot] 到环境变量 [NODE_HOME] ，例如：",
+	"Registry config noderoot to NODE_HOME and add to Path.":                         "设置 noderoot 到 NODE_HOME 并添加到 Path 。",
+
+	// help search
+	"Search and Print Node.js version detail usage wildcard mode or regexp mode":          "使用通配符或正则表达式查询并输出 Node.js 版本详细信息",
+	"Search  and Print Node.js version detail usage wildcard mode or regexp mode. e.g. :": "使用通配符或正则表达式查询并输出 Node.js 版本详细信息，例如：",
+	"Search and Print all Node.js versions detail, consistent with gnvm ls -r -d.":        "查询并输出全部 Node.js 版本详细信息，与 gnvm ls -r -d 一致。",
+	"Search and Print 0.0.0  ~ 0.99.99 range Node.js version detail.":                     "查询并输出 0.0.0 ~ 0.99.99 范围的 Node.js 版本详细信息。",
+	"Search and Print 0.10.0 ~ 0.10.99 range Node.js version detail.":                     "查询并输出 0.10.0 ~ 0.10.99 范围的 Node.js 版本详细信息。",
+	"Search and Print <regexp> Node.js version detail.":                                   "查询并输出匹配 <regexp> 的 Node.js 版本详细信息。",
+	"Search and Print latest   Node.js version detail.":                                   "查询并输出 latest Node.js 版本详细信息。",
+	"Search and Print 0.10.10  Node.js version detail.":                                   "查询并输出 0.10.10 Node.js 版本详细信息。",
+	"Search and Print Node.js version detail only include columns.":                       "查询并输出 Node.js 版本详细信息，只包括指定的列。",
+
+	// help npm
+	"NPM version management":                              "npm 版本管理",
+	"Download and intall any npm version. e.g. :":         "下载并安装任意 npm 版本，例如：",
+	"Install x.xx.xx npm version.":                        "安装 x.xx.xx npm 版本。",
+	"Install latest  npm version.":                        "安装 latest npm 版本。",
+	"Install local Node.js version matching npm version.": "安装与本地 Node.js 版本对应的 npm 版本。",
+
+	// help doctor
+	"Check gnvm setup and print pass/warn/fail lines with fixes":                                      "检查 gnvm 环境并输出 pass / warn / fail 结果与修复建议",
+	"Check gnvm setup and print pass/warn/fail lines with fixes. e.g. :":                              "检查 gnvm 环境并输出 pass / warn / fail 结果与修复建议，例如：",
+	"Check noderoot, .gnvmrc, global and latest version, Path, NODE_HOME, npm, session and registry.": "检查 noderoot 、 .gnvmrc 、 global 与 latest 版本、 Path 、 NODE_HOME 、 npm 、 session 以及 registry 。",
+	"Check assign noderoot.": "检查指定的 noderoot 。",
+
+	// help import
+	"Import Node.js versions from nvm-windows, nvm, nodist or fnm":               "从 nvm-windows 、 nvm 、 nodist 或 fnm 导入 Node.js 版本",
+	"Import Node.js versions already installed by other version manager, e.g. :": "导入其它版本管理工具已安装的 Node.js 版本，例如：",
+	"Import from %NVM_HOME%, and set %NVM_SYMLINK% version to global.":           "从 %NVM_HOME% 导入，并将 %NVM_SYMLINK% 的版本设置为全局版本。",
+	"Import from assign folder, and set alias default version to global.":        "从指定的文件夹导入，并将 alias default 的版本设置为全局版本。",
+	"Import from %NODIST_PREFIX% v and v-x64 folders.":                           "从 %NODIST_PREFIX% 的 v 与 v-x64 文件夹导入。",
+	"Import usage link, mode include: copy, move and link, default is copy.":     "使用链接导入，方式包括： copy 、 move 与 link ，默认为 copy 。",
+	"version manager, include: nvm-windows, nvm, nodist and fnm.":                "版本管理工具，包括： nvm-windows 、 nvm 、 nodist 与 fnm 。",
+	"import mode, include: copy, move and link.":                                 "导入方式，包括： copy 、 move 与 link 。",
+	"%v must be one of %v, please check your input. See '%v'.\n":                 "%v 必须为 %v 之一，请检查输入。参见 '%v' 。\n",
+	"not found any Node.js version of %v in %v.\n":                               "未找到任何 %v 的 Node.js 版本，路径为 %v 。\n",
+	"found %v Node.js versions of %v in %v.\n":                                   "找到 %v 个 %v 的 Node.js 版本，路径为 %v 。\n",
+	"%v folder exist, skip %v.\n":                                                "%v 文件夹已存在，跳过 %v 。\n",
+	"Import %v success, %v from %v.\n":                                           "导入 %v 成功，方式为 %v ，来源为 %v 。\n",
+	"%v default version is %v.\n":                                                "%v 的默认版本为 %v 。\n",
+
+	// help sync
+	"Sync local Node.js versions by gnvm.json or gnvm.toml manifest":          "根据 gnvm.json 或 gnvm.toml 清单同步本地 Node.js 版本",
+	"Sync local Node.js versions, global, latest and npm by manifest, e.g. :": "根据清单同步本地 Node.js 版本、 global 、 latest 以及 npm ，例如：",
+	"Sync by gnvm.json or gnvm.toml of current folder.":                       "根据当前文件夹的 gnvm.json 或 gnvm.toml 同步。",
+	"Sync by assign manifest.":                                                "根据指定的清单同步。",
+	"Sync and remove local Node.js versions which not in manifest.":           "同步并删除不在清单中的本地 Node.js 版本。",
+	"Only print diff, '+' is install, '-' is remove, '~' is change.":          "只输出差异， '+' 为安装， '-' 为删除， '~' 为变更。",
+	"remove local Node.js versions which not in manifest.":                    "删除不在清单中的本地 Node.js 版本。",
+	"only print diff, not install or remove.":                                 "只输出差异，不安装也不删除。",
+	"not found %v or %v in %v. See '%v'.\n":                                   "未找到 %v 或 %v ，路径为 %v 。参见 '%v' 。\n",
+	"sync %v to %v.\n":                                                        "同步 %v 到 %v 。\n",
+	"Already in sync with %v.\n":                                              "已与 %v 同步。\n",
+	"install %v fail, Error: %v.\n":                                           "安装 %v 失败，错误： %v 。\n",
+	"Node.js version %v install success.\n":                                   "Node.js 版本 %v 安装成功。\n",
+	"%v Node.js versions install fail. See '%v'.\n":                           "%v 个 Node.js 版本安装失败。参见 '%v' 。\n",
+	"install npm %v fail, Error: %v.\n":                                       "安装 npm %v 失败，错误： %v 。\n",
+	"npm version %v install success.\n":                                       "npm 版本 %v 安装成功。\n",
+
+	// help export
+	"Export lockfile of installed Node.js versions and SHA-256 checksums":                                    "导出已安装 Node.js 版本及其 SHA-256 校验值的锁定文件",
+	"Export lockfile of installed Node.js versions, arch, registry, SHA-256, global, latest and npm, e.g. :": "导出已安装 Node.js 版本的锁定文件，包括架构、 registry 、 SHA-256 、 global 、 latest 以及 npm ，例如：",
+	"Export to gnvm-lock.json of current folder.":                                                            "导出到当前文件夹的 gnvm-lock.json 。",
+	"Export to assign lockfile.":                                                                             "导出到指定的锁定文件。",
+	"Verify current machine by gnvm-lock.json, exit code is 5 when different.":                               "根据 gnvm-lock.json 校验当前机器，不一致时退出码为 5 。",
+	"verify current machine by lockfile, not write.":                                                         "根据锁定文件校验当前机器，不写入。",
+	"Export %v Node.js versions to %v.\n":                                                                    "导出 %v 个 Node.js 版本到 %v 。\n",
+	"%v is in lockfile, but not installed.\n":                                                                "%v 在锁定文件中，但未安装。\n",
+	"%v is installed, but not in lockfile.\n":                                                                "%v 已安装，但不在锁定文件中。\n",
+	"%v is %v, lockfile is %v.\n":                                                                            "%v 为 %v ，锁定文件中为 %v 。\n",
+	"%v %v is %v, lockfile is %v.\n":                                                                         "%v 的 %v 为 %v ，锁定文件中为 %v 。\n",
+	"verify fail, found %v differences from %v.\n":                                                           "校验失败，发现 %v 处与 %v 不一致。\n",
+	"Verify success, %v Node.js versions are the same as %v.\n":                                              "校验成功， %v 个 Node.js 版本与 %v 一致。\n",
+
+	// help verify
+	"Verify installed Node.js versions by SHA-256 and node --version":                                     "根据 SHA-256 与 node --version 校验已安装的 Node.js 版本",
+	"Verify installed Node.js versions by SHASUMS256.txt or recorded SHA-256, and node --version, e.g. :": "根据 SHASUMS256.txt 或已记录的 SHA-256 以及 node --version 校验已安装的 Node.js 版本，例如：",
+	"Verify all Node.js versions and global node.exe.":                                                    "校验全部 Node.js 版本以及全局 node.exe 。",
+	"Verify 20.1.0 Node.js version.":                                                                      "校验 20.1.0 Node.js 版本。",
+	"Verify all and redownload corrupted Node.js versions.":                                               "校验全部版本并重新下载已损坏的 Node.js 版本。",
+	"redownload corrupted Node.js versions and copy global node.exe again.":                               "重新下载已损坏的 Node.js 版本，并重新复制全局 node.exe 。",
+	"Start repair Node.js version %v, please wait.\n":                                                     "开始修复 Node.js 版本 %v ，请稍候。\n",
+	"repair %v fail, Error: %v.\n":                                                                        "修复 %v 失败，错误： %v 。\n",
+	"Repair %v success.\n":                                                                                "修复 %v 成功。\n",
+	"found corrupted Node.js versions [%v]. See '%v'.\n":                                                  "发现已损坏的 Node.js 版本 [%v] 。参见 '%v' 。\n",
+	"Verify success, %v Node.js versions are ok.\n":                                                       "校验成功， %v 个 Node.js 版本正常。\n",
+	"%v ok, sha256 %v from %v.\n":                                                                         "%v 正常， sha256 为 %v ，来源为 %v 。\n",
+	"%v node --version is %v, but not found any checksum.\n":                                              "%v 的 node --version 为 %v ，但未找到任何校验值。\n",
+	"%v not found %v.\n":                                                                                  "%v 未找到 %v 。\n",
+	"%v node --version is %v, arch is %v, not match folder name.\n":                                       "%v 的 node --version 为 %v ，架构为 %v ，与文件夹名称不一致。\n",
+	"%v sha256 is %v, but %v is %v.\n":                                                                    "%v 的 sha256 为 %v ，但 %v 中为 %v 。\n",
+
+	// help prune
+	"Remove old Node.js versions by keep policies": "根据保留策略删除旧的 Node.js 版本",
+	"Remove old Node.js versions by keep policies, global, latest and gnvm.json or gnvm.toml versions are always protected, e.g. :": "根据保留策略删除旧的 Node.js 版本， global 、 latest 以及 gnvm.json 或 gnvm.toml 中的版本始终受保护，例如：",
+	"Keep the newest 5 Node.js versions.":                                "保留最新的 5 个 Node.js 版本。",
+	"Keep the newest Node.js version of each major.":                     "保留每个主版本中最新的 Node.js 版本。",
+	"Remove Node.js versions installed 180 days ago.":                    "删除 180 天前安装的 Node.js 版本。",
+	"Remove Node.js versions not used by 'gnvm use' since 2024-01-01.":   "删除自 2024-01-01 起未被 'gnvm use' 使用过的 Node.js 版本。",
+	"Only print Node.js versions would be removed and free space.":       "只输出将被删除的 Node.js 版本以及释放的空间。",
+	"only print Node.js versions would be removed and free space.":       "只输出将被删除的 Node.js 版本以及释放的空间。",
+	"keep the newest N Node.js versions.":                                "保留最新的 N 个 Node.js 版本。",
+	"keep the newest N Node.js versions of each major.":                  "保留每个主版本中最新的 N 个 Node.js 版本。",
+	"remove Node.js versions installed before, e.g. 180d 2w 2024-01-01.": "删除在此之前安装的 Node.js 版本，例如 180d 2w 2024-01-01 。",
+	"remove Node.js versions not used since, e.g. 90d 2024-01-01.":       "删除自此之后未使用过的 Node.js 版本，例如 90d 2024-01-01 。",
+	"flag %v and %v must be positive number. See '%v'.\n":                "%v 与 %v 参数必须为正数。参见 '%v' 。\n",
+	"'%v' need at least one policy, include: %v. See '%v'.\n":            "'%v' 至少需要一个策略，包括： %v 。参见 '%v' 。\n",
+	"protect Node.js versions of %v.\n":                                  "保护 %v 中的 Node.js 版本。\n",
+	"Nothing to prune.\n":                                                "没有需要删除的版本。\n",
+	"  - %v %v, installed %v, last used %v\n":                            "  - %v %v ，安装于 %v ，最近使用于 %v\n",
+	"%v Node.js versions would be removed, free %v.\n":                   "将删除 %v 个 Node.js 版本，释放 %v 。\n",
+	"%v free.\n": "已释放 %v 。\n",
+
+	// help ls -L
+	"Print local  Node.js version list with arch, size, install date, registry, npm, lts, last used and total disk usage.": "输出本地 Node.js 版本列表，包括架构、磁盘占用、安装日期、安装源、 npm 、 lts 、最近使用时间以及总磁盘占用。",
+	"print local Node.js version list with arch, size, install date, registry, npm, lts and last used.":                    "输出本地 Node.js 版本列表，包括架构、磁盘占用、安装日期、安装源、 npm 、 lts 以及最近使用时间。",
+	"%v Node.js versions, total %v.\n": "共 %v 个 Node.js 版本，总计 %v 。\n",
+
+	// help outdated upgrade
+	"Compare local Node.js versions to the newest patch in each line":                                                                  "对比本地 Node.js 版本与各版本线中最新的补丁版本",
+	"Compare local Node.js versions to the newest remote patch in the same line, flag security releases and end-of-life lines, e.g. :": "对比本地 Node.js 版本与同一版本线中最新的远程补丁版本，并标记安全更新以及已停止维护的版本线，例如：",
+	"Print local Node.js versions next to the newest patch, line is major, 0.x is minor.":                                              "输出本地 Node.js 版本以及对应的最新补丁版本，版本线为主版本， 0.x 为次版本。",
+	"Print as json, include: folder, version, latest, target, line, security, eol and end.":                                            "以 json 格式输出，包括： folder 、 version 、 latest 、 target 、 line 、 security 、 eol 以及 end 。",
+	"Install the newest patch in the line of local Node.js versions":                                                                   "安装本地 Node.js 版本所在版本线中最新的补丁版本",
+	"Install the newest remote patch in the same line of local Node.js versions, old versions are not removed, e.g. :":                 "安装本地 Node.js 版本所在版本线中最新的远程补丁版本，旧版本不会被删除，例如：",
+	"Install the newest v18 patch, e.g. 18.20.4.":                                                                                      "安装最新的 v18 补丁版本，例如 18.20.4 。",
+	"Install the newest patch of all outdated Node.js versions.":                                                                       "为所有过期的 Node.js 版本安装最新的补丁版本。",
+	"Install the newest patch of global, and move global and latest to it.":                                                            "安装 global 的最新补丁版本，并将 global 与 latest 迁移到该版本。",
+	"move global and latest to the new patch.":                                                                                         "将 global 与 latest 迁移到新的补丁版本。",
+	"compare local Node.js versions with %v.\n":                                                                                        "对比本地 Node.js 版本与 %v 。\n",
+	"All Node.js versions are up to date.\n":                                                                                           "所有 Node.js 版本均为最新。\n",
+	"%v of %v Node.js versions are outdated, use '%v' to install the newest patches.\n":                                                "%v / %v 个 Node.js 版本已过期，请使用 '%v' 安装最新的补丁版本。\n",
+	"%v is not installed or not a release version. See '%v'.\n":                                                                        "%v 未安装或不是正式发布版本。参见 '%v' 。\n",
+	"%v is the newest version of line %v, don't need to upgrade.\n":                                                                    "%v 已是版本线 %v 中的最新版本，无需升级。\n",
+	"Upgrade %v to %v.\n":              "升级 %v 到 %v 。\n",
+	"%v is %v, use '%v' to migrate.\n": "%v 仍为 %v ，请使用 '%v' 迁移。\n",
+
+	// help advisory
+	"get Node.js release schedule error, skip end-of-life and security check. Error: %v\n":                    "获取 Node.js 发布计划错误，跳过停止维护与安全更新检查。错误： %v\n",
+	"Node.js %v line %v is end-of-life since %v, please upgrade. Silence by '%v'.\n":                          "Node.js %v 所在的版本线 %v 已于 %v 停止维护，请升级。可使用 '%v' 关闭此提示。\n",
+	"Node.js %v has a newer security release %v, please use '%v'. Silence by '%v'.\n":                         "Node.js %v 存在更新的安全版本 %v ，请使用 '%v' 。可使用 '%v' 关闭此提示。\n",
+	"%v Node.js versions are end-of-life or have a newer security release. See '%v'.\n":                       "%v 个 Node.js 版本已停止维护或存在更新的安全版本。参见 '%v' 。\n",
+	"Custom  is valid url, schedule.json folder, e.g. https://raw.githubusercontent.com/nodejs/Release/main/": "自定义值必须为有效的 url ，即 schedule.json 所在的目录，例如 https://raw.githubusercontent.com/nodejs/Release/main/",
+	"Custom  is comma separated Node.js versions, not warn end-of-life and security release.":                 "自定义值为逗号分隔的 Node.js 版本，这些版本不再提示停止维护与安全更新。",
+
+	// help migrate-globals
+	"Download and reinstall global npm packages of global version.":                                              "下载并重新安装 global 版本的全局 npm 包。",
+	"reinstall latest versions of global npm packages instead of pinned versions.":                               "重新安装全局 npm 包的最新版本，而不是原有版本。",
+	"reinstall global npm packages of this Node.js version, e.g. global 18.16.0.":                                "重新安装该 Node.js 版本的全局 npm 包，例如 global 18.16.0 。",
+	"Reinstall global npm packages of one Node.js version to another":                                            "将一个 Node.js 版本的全局 npm 包重新安装到另一个版本",
+	"Reinstall global npm packages of <from> Node.js version to <to> Node.js version, usage npm of <to>, e.g. :": "将 <from> Node.js 版本的全局 npm 包重新安装到 <to> Node.js 版本，使用 <to> 的 npm ，例如：",
+	"Reinstall global npm packages of 18.16.0 to 20.1.0 at pinned versions.":                                     "将 18.16.0 的全局 npm 包按原有版本重新安装到 20.1.0 。",
+	"Reinstall global npm packages of global version to latest version.":                                         "将 global 版本的全局 npm 包重新安装到 latest 版本。",
+	"Reinstall latest versions of the packages.":                                                                 "重新安装这些包的最新版本。",
+	"flag %v must be used with only one %v, e.g. '%v'. See '%v'.\n":                                              "%v 参数只能与一个 %v 一起使用，例如 '%v' 。参见 '%v' 。\n",
+	"%v and %v are the same Node.js version %v. See '%v'.\n":                                                     "%v 与 %v 为同一个 Node.js 版本 %v 。参见 '%v' 。\n",
+	"%v not have any global npm package, not need to migrate.\n":                                                 "%v 没有任何全局 npm 包，无需迁移。\n",
+	"Reinstall %v global npm packages of %v with npm of %v, please wait.\n":                                      "重新安装 %v 个全局 npm 包，来源 %v ，使用 %v 的 npm ，请稍等。\n",
+	"  + %v\n": "  + %v\n",
+	"Migrate success, %v global npm packages are installed to %v.\n": "迁移成功， %v 个全局 npm 包已安装到 %v 。\n",
+
+	// help doctor
+	"%v folder exist":                                               "%v 文件夹存在",
+	"%v folder is not exist %v":                                     "%v 文件夹下不存在 %v",
+	"%v is %v, but config %v is %v":                                 "%v 为 %v ，但 config %v 为 %v",
+	"%v matches config %v":                                          "%v 与 config %v 一致",
+	"%v matches node --version":                                     "%v 与 node --version 一致",
+	"%v parse success":                                              "%v 解析成功",
+	"%v reachable":                                                  "%v 可以访问",
+	"%v resolves to %v, but gnvm root is %v":                        "%v 推断为 %v ，但 gnvm 根目录为 %v",
+	"%v response code is %v":                                        "%v 响应码为 %v",
+	"%v unreachable, Error: %v":                                     "%v 无法访问，错误： %v",
+	"GNVM_SESSION_NODE_HOME is %v, but %v is not exist":             "GNVM_SESSION_NODE_HOME 为 %v ，但 %v 不存在",
+	"GNVM_SESSION_NODE_HOME is %v, but folder is not exist %v":      "GNVM_SESSION_NODE_HOME 为 %v ，但文件夹下不存在 %v",
+	"another %v in %v is earlier than gnvm root %v":                 "另一个 %v 位于 %v ，在 gnvm 根目录 %v 之前",
+	"config %v is %v, but gnvm root is %v":                          "config %v 为 %v ，但 gnvm 根目录为 %v",
+	"config %v is %v, but node --version is %v":                     "config %v 为 %v ，但 node --version 为 %v",
+	"config %v is %v, but not found %v":                             "config %v 为 %v ，但未找到 %v",
+	"create the folder or set %v to an exist folder":                "创建该文件夹，或将 %v 设置为已存在的文件夹",
+	"current is session environment %v, some commands are disabled": "当前为 session 环境 %v ，部分命令已禁用",
+	"environment variable is not set":                               "环境变量未设置",
+	"first %v in Path is %v":                                        "Path 中第一个 %v 位于 %v",
+	"first %v in Path is session %v":                                "Path 中第一个 %v 位于 session %v",
+	"gnvm root %v folder is not exist":                              "gnvm 根目录 %v 文件夹不存在",
+	"gnvm root is %v, resolved by %v":                               "gnvm 根目录为 %v ，由 %v 推断",
+	"gnvm root is resolved by current folder, it changes with the working directory": "gnvm 根目录由当前文件夹推断，会随工作目录变化",
+	"latest version is %v": "latest 版本为 %v",
+	"local npm version is %v, but global Node.js bundled npm version is %v":    "本地 npm 版本为 %v ，但全局 Node.js 自带的 npm 版本为 %v",
+	"local npm version is %v, get Node.js bundled npm version fail, Error: %v": "本地 npm 版本为 %v ，获取 Node.js 自带的 npm 版本失败，错误： %v",
+	"move %v before %v in Path, or remove it":                                  "在 Path 中将 %v 移到 %v 之前，或删除后者",
+	"not found %v in Path":                        "Path 中未找到 %v",
+	"not found global node.exe":                   "未找到全局 node.exe",
+	"not found npm in %v":                         "%v 下未找到 npm",
+	"not in session environment":                  "当前不是 session 环境",
+	"npm %v matches global Node.js bundled npm":   "npm %v 与全局 Node.js 自带的 npm 一致",
+	"set %v or remove the other folder from Path": "设置 %v ，或从 Path 中删除另一个文件夹",
+	"set environment variable %v or use '%v'":     "设置环境变量 %v 或使用 '%v'",
+	"use '%v' or '%v'":                            "使用 '%v' 或 '%v'",
+	"use '%v'":                                    "使用 '%v'",
+
+	// help install progress
+	"End download.\n": "下载结束。\n",
+
+	// help config user layer
+	"user config %v of %v ignored, Error: %v.\n": "已忽略用户配置 %v ，文件 %v ，错误： %v 。\n",
+}
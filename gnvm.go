@@ -1,11 +1,14 @@
 package main
 
 import (
+	// go
+	"os"
+
 	// local
-	_ "gnvm/command"
+	"gnvm/command"
 	_ "gnvm/util"
 )
 
 func main() {
-
+	os.Exit(command.Execute())
 }
@@ -3,19 +3,17 @@ module gnvm
 go 1.21.5
 
 require (
-	github.com/Kenshin/cprint v0.0.0-20140603071510-5d2b3710156e
 	github.com/Kenshin/curl v0.0.0-20160421052854-aeef514670e2
 	github.com/Kenshin/regedit v0.0.0-20160325040319-729a79824571
 	github.com/bitly/go-simplejson v0.5.1
+	github.com/daviddengcn/go-colortext v1.0.0
 	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5
 	github.com/tsuru/config v0.0.0-20201023175036-375aaee8b560
 )
 
 require (
-	github.com/akavel/rsrc v0.10.2 // indirect
-	github.com/daviddengcn/go-colortext v1.0.0 // indirect
 	github.com/howeyc/fsnotify v0.9.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	gopkg.in/yaml.v2 v2.3.0 // indirect
 )
@@ -0,0 +1,338 @@
+/*
+Fake registry, process execution and gnvm root for hermetic tests, not usage in gnvm.exe
+*/
+package fake
+
+import (
+	// go
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Fake Node.js release
+
+  - Version:  x.xx.xx
+  - Date:     publish date
+  - NPM:      bundled npm version
+  - LTS:      lts codename, when "" not lts
+  - V8:       bundled v8 version
+  - OpenSSL:  bundled openssl version
+  - Modules:  NODE_MODULE_VERSION( ABI )
+  - Security: true when security release
+  - Files:    index.json files, when nil, usage win-x64-exe and win-x86-exe
+*/
+type Release struct {
+	Version  string
+	Date     string
+	NPM      string
+	LTS      string
+	V8       string
+	OpenSSL  string
+	Modules  string
+	Security bool
+	Files    []string
+}
+
+/*
+Default fake releases, sort by version desc, the first is latest
+*/
+var Releases = []Release{
+	{"20.1.0", "2023-05-03", "9.6.4", "", "11.3.244.8-node.6", "3.0.8+quic", "115", false, nil},
+	{"18.16.0", "2023-04-12", "9.5.1", "Hydrogen", "10.2.154.26-node.26", "3.0.8+quic", "108", false, nil},
+	{"16.20.0", "2023-03-28", "8.19.4", "Gallium", "9.4.146.26-node.26", "1.1.1t+quic", "93", true, nil},
+}
+
+/*
+Fake registry, include:
+  - /index.json
+  - /schedule.json, every line end-of-life is one year later, usage Set to replace
+  - /latest/SHASUMS256.txt
+  - /v<version>/SHASUMS256.txt
+  - /v<version>/win-<arch>/node.exe by files win-<arch>-exe
+  - /v<version>/node-v<version>-win-<arch>.zip by files win-<arch>-zip
+  - /npm/latest and /npm/-/npm-<version>.tgz
+
+Field:
+  - Server:   httptest server
+  - URL:      node registry, e.g. http://127.0.0.1:xxxx/
+  - NPM:      npm registry,  e.g. http://127.0.0.1:xxxx/npm/
+  - Requests: request paths
+*/
+type Registry struct {
+	Server   *httptest.Server
+	URL      string
+	NPM      string
+	Requests []string
+	files    map[string][]byte
+	mutex    sync.Mutex
+}
+
+/*
+Create fake registry, closed when test finish
+
+Param:
+  - t:        *testing.T
+  - releases: when nil, usage Releases
+
+Return:
+  - *Registry
+*/
+func NewRegistry(t testing.TB, releases []Release) *Registry {
+	if releases == nil {
+		releases = Releases
+	}
+	reg := &Registry{files: make(map[string][]byte)}
+
+	index := []map[string]interface{}{}
+	for _, r := range releases {
+		lts := interface{}(false)
+		if r.LTS != "" {
+			lts = r.LTS
+		}
+		files := r.Files
+		if files == nil {
+			files = []string{"win-x64-exe", "win-x86-exe"}
+		}
+		index = append(index, map[string]interface{}{
+			"version":  "v" + r.Version,
+			"date":     r.Date,
+			"files":    files,
+			"npm":      r.NPM,
+			"v8":       r.V8,
+			"openssl":  r.OpenSSL,
+			"modules":  r.Modules,
+			"lts":      lts,
+			"security": r.Security,
+		})
+
+		sums := ""
+		for _, file := range files {
+			arr := strings.Split(file, "-")
+			if len(arr) != 3 || arr[0] != "win" {
+				continue
+			}
+			switch arch, kind := arr[1], arr[2]; kind {
+			case util.FILE_EXE:
+				name := "win-" + arch + "/" + util.NODE
+				reg.files["/v"+r.Version+"/"+name] = Node(r.Version, arch)
+				sums += shasum(reg.files["/v"+r.Version+"/"+name], name)
+			case util.FILE_ZIP:
+				name := "node-v" + r.Version + "-win-" + arch + ".zip"
+				reg.files["/v"+r.Version+"/"+name] = Zip(t, r.Version, arch)
+				sums += shasum(reg.files["/v"+r.Version+"/"+name], name)
+			}
+		}
+		reg.files["/v"+r.Version+"/"+util.SHASUMS] = []byte(sums)
+		reg.files["/npm/-/npm-"+r.NPM+".tgz"] = NPM(t, r.NPM)
+	}
+	body, err := json.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg.files["/"+util.NODELIST] = body
+
+	schedule, end := map[string]map[string]string{}, time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	for _, r := range releases {
+		line := map[string]string{"start": r.Date, "end": end}
+		if r.LTS != "" {
+			line["codename"] = r.LTS
+		}
+		schedule[util.NodeLine(r.Version)] = line
+	}
+	if body, err = json.Marshal(schedule); err != nil {
+		t.Fatal(err)
+	}
+	reg.files["/"+util.SCHEDULE] = body
+	if len(releases) > 0 {
+		latest := releases[0].Version
+		reg.files["/"+util.LATEST+"/"+util.SHASUMS] = []byte(fmt.Sprintf("%x  node-v%v-headers.tar.gz\n", sha256.Sum256(nil), latest))
+		reg.files["/npm/"+util.LATEST] = []byte(`{"name":"npm","version":"` + releases[0].NPM + `"}`)
+	}
+
+	reg.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.mutex.Lock()
+		reg.Requests = append(reg.Requests, r.URL.Path)
+		body, ok := reg.files[r.URL.Path]
+		reg.mutex.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.Write(body)
+	}))
+	reg.URL = reg.Server.URL + "/"
+	reg.NPM = reg.Server.URL + "/npm/"
+	t.Cleanup(reg.Server.Close)
+	return reg
+}
+
+/*
+Set or replace registry file
+
+Param:
+  - path: e.g. /v20.1.0/win-x64/node.exe
+  - body: file content, when nil, remove file
+*/
+func (reg *Registry) Set(path string, body []byte) {
+	reg.mutex.Lock()
+	defer reg.mutex.Unlock()
+	if body == nil {
+		delete(reg.files, path)
+		return
+	}
+	reg.files[path] = body
+}
+
+func shasum(body []byte, name string) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]) + "  " + name + "\n"
+}
+
+/*
+Fake node.exe binary, first line is version, x86 binary include PE i386 signature
+
+Param:
+  - version: x.xx.xx
+  - arch:    include: "x86" "x64"
+
+Return:
+  - []byte
+*/
+func Node(version, arch string) []byte {
+	body := "v" + version + "\n"
+	if arch == "x86" {
+		body += "PE\x00\x00\x4c\x01"
+	}
+	return []byte(body)
+}
+
+/*
+Fake Node.js zip, include node-v<version>-win-<arch>/node.exe
+*/
+func Zip(t testing.TB, version, arch string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("node-v" + version + "-win-" + arch + "/" + util.NODE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(Node(version, arch)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+/*
+Fake npm tgz, include package/package.json package/bin/npm package/bin/npm.cmd
+*/
+func NPM(t testing.TB, version string) []byte {
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	files := map[string]string{
+		"package/package.json": `{"name":"npm","version":"` + version + `"}`,
+		"package/bin/npm":      version + "\n",
+		"package/bin/npm.cmd":  version + "\n",
+	}
+	for _, name := range []string{"package/package.json", "package/bin/npm", "package/bin/npm.cmd"} {
+		body := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+/*
+Fake process execution, print the first line of the executable file, e.g. node.exe --version print v20.1.0
+
+  - Calls: executed commands
+*/
+type Exec struct {
+	Calls []string
+}
+
+func (e *Exec) Output(name string, args ...string) ([]byte, error) {
+	e.Calls = append(e.Calls, strings.TrimSpace(name+" "+strings.Join(args, " ")))
+	file, err := util.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && line == "" {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(line) + "\n"), nil
+}
+
+/*
+Create temp gnvm root, replace util.Runner with *Exec and restore when test finish
+
+Param:
+  - t: *testing.T
+
+Return:
+  - root: temp folder
+  - exec: *Exec
+*/
+func Root(t testing.TB) (string, *Exec) {
+	root := t.TempDir()
+	exec, runner := new(Exec), util.Runner
+	util.Runner = exec
+	t.Cleanup(func() { util.Runner = runner })
+	return root, exec
+}
+
+/*
+Write fake node.exe to <root>/<folder>/node.exe, when folder == "", write <root>/node.exe
+
+Param:
+  - t:       *testing.T
+  - root:    gnvm root
+  - folder:  e.g. x.xx.xx x.xx.xx-x86
+  - version: x.xx.xx
+  - arch:    include: "x86" "x64"
+*/
+func Install(t testing.TB, root, folder, version, arch string) {
+	dir := filepath.Join(root, folder)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, util.NODE), Node(version, arch), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,171 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// local
+	"gnvm/util"
+)
+
+const (
+	ADVISORY     = "gnvm-advisory.json"
+	ADVISORY_TTL = 24 * time.Hour
+)
+
+/*
+Cached Node.js release schedule and security releases, save as <root>/gnvm-advisory.json
+
+  - Date:     fetch time, RFC3339
+  - Registry: index.json registry
+  - From:     schedule.json folder
+  - Schedule: schedule.json lines, key is line, e.g. v18 v0.12
+  - Security: security release versions of index.json, e.g. 18.16.1
+*/
+type Advisory struct {
+	Date     string          `json:"date"`
+	Registry string          `json:"registry"`
+	From     string          `json:"from"`
+	Schedule map[string]Line `json:"schedule"`
+	Security []string        `json:"security"`
+}
+
+/*
+Advisory warning of Node.js version
+
+  - Version:  x.xx.xx
+  - Line:     release line, e.g. v16
+  - EOL:      true when line is past end-of-life
+  - End:      end-of-life date of line
+  - Security: the newest security release newer than Version in the same line, when not found, it is ""
+*/
+type Warning struct {
+	Version  string `json:"version"`
+	Line     string `json:"line"`
+	EOL      bool   `json:"eol"`
+	End      string `json:"end"`
+	Security string `json:"security"`
+}
+
+/*
+Return cached advisory, when cache is older than ADVISORY_TTL or registry changed, fetch again
+
+Param:
+  - refresh: when true, always fetch
+
+Return:
+  - *Advisory: when fetch fail, return stale cache
+  - error:     fetch fail and not found any cache
+*/
+func (m *Manager) Advisory(refresh bool) (*Advisory, error) {
+	path := filepath.Join(m.root, ADVISORY)
+	cache := readAdvisory(path)
+	if cache != nil && !refresh && cache.Registry == m.registry && cache.From == m.schedule {
+		if date, err := time.Parse(time.RFC3339, cache.Date); err == nil && time.Since(date) < ADVISORY_TTL {
+			return cache, nil
+		}
+	}
+
+	advisory, err := m.fetchAdvisory()
+	if err != nil {
+		if cache == nil {
+			return nil, err
+		}
+		m.logger.Printf("%v, usage cache %v", err, path)
+		return cache, nil
+	}
+	file, err := util.FileSystem.Create(path)
+	if err != nil {
+		m.logger.Printf("create %v Error: %v", path, err)
+		return advisory, nil
+	}
+	err = json.NewEncoder(file).Encode(advisory)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		m.logger.Printf("write %v Error: %v", path, err)
+	}
+	return advisory, nil
+}
+
+/*
+Return <root>/gnvm-advisory.json cache without fetch, stale cache is also returned, usage offline commands, e.g. gnvm ls
+
+Return:
+  - *Advisory: when cache not exist, invalid or registry changed, return nil
+*/
+func (m *Manager) CachedAdvisory() *Advisory {
+	cache := readAdvisory(filepath.Join(m.root, ADVISORY))
+	if cache == nil || cache.Registry != m.registry || cache.From != m.schedule {
+		return nil
+	}
+	return cache
+}
+
+/*
+Read <root>/gnvm-advisory.json, when not exist or invalid, return nil
+*/
+func readAdvisory(path string) *Advisory {
+	file, err := util.FileSystem.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	advisory := new(Advisory)
+	if err := json.NewDecoder(file).Decode(advisory); err != nil {
+		return nil
+	}
+	return advisory
+}
+
+func (m *Manager) fetchAdvisory() (*Advisory, error) {
+	remotes, err := m.listRemote(m.registry)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := m.Schedule()
+	if err != nil {
+		return nil, err
+	}
+	advisory := &Advisory{time.Now().Format(time.RFC3339), m.registry, m.schedule, schedule, []string{}}
+	for _, r := range remotes {
+		if r.Security {
+			advisory.Security = append(advisory.Security, strings.TrimPrefix(r.Version, "v"))
+		}
+	}
+	return advisory, nil
+}
+
+/*
+Return advisory warning of Node.js version
+
+Param:
+  - version: x.xx.xx x.xx.xx-x86, channel version and custom build folder return nil
+  - now:     usage check end-of-life
+
+Return:
+  - *Warning: when not end-of-life and not found newer security release, return nil
+*/
+func (a *Advisory) Warn(version string, now time.Time) *Warning {
+	ver, io, _, _, err := util.ParseNodeVer(version)
+	if err != nil || io || strings.Contains(ver, util.CHANNEL_SEP) {
+		return nil
+	}
+	w := &Warning{Version: ver, Line: util.NodeLine(ver)}
+	if line, ok := a.Schedule[w.Line]; ok {
+		w.EOL, w.End = line.EOL(now), line.End
+	}
+	for _, v := range a.Security {
+		if util.NodeLine(v) == w.Line && util.CompareNodeVer(v, ver) > 0 && (w.Security == "" || util.CompareNodeVer(v, w.Security) > 0) {
+			w.Security = v
+		}
+	}
+	if !w.EOL && w.Security == "" {
+		return nil
+	}
+	return w
+}
@@ -0,0 +1,119 @@
+package manager
+
+import (
+	// go
+	"path"
+	"path/filepath"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Install custom Node.js build from local archive or url to <root>/<name>/node.exe, verify node.exe --version and write util.BUILD
+
+Param:
+  - from: local path or http(s) url, include: .zip and .exe, zip must include node.exe or <folder>/node.exe
+  - name: local folder name, e.g. 18.19.0-patched, when name == "", get from official archive name, see util.BuildName
+
+Return:
+  - build: installed custom build
+  - error
+*/
+func (m *Manager) InstallFrom(from, name string) (*util.Build, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		if name = util.BuildName(from); name == "" {
+			return nil, util.Errorf(util.EXIT_USAGE, "can not get Node.js version from %v, please use --as <name>", from)
+		}
+	}
+	if !util.VerifyBuildName(name) {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v not a valid name, e.g. x.xx.xx x.xx.xx-x86 x.xx.xx-<tag>", name)
+	}
+
+	remote := strings.HasPrefix(from, "https://") || strings.HasPrefix(from, "http://")
+	base := filepath.Base(from)
+	if remote {
+		base = path.Base(strings.SplitN(from, "?", 2)[0])
+	} else if _, err := util.FileSystem.Stat(from); err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v is not exist", from)
+	}
+	ext := strings.ToLower(path.Ext(base))
+	if ext != ".zip" && ext != ".exe" {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v not support, include: .zip and .exe", base)
+	}
+
+	dst := filepath.Join(m.root, name)
+	if util.IsDirExist(dst, util.NODE) {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v folder exist, please uninstall it first", name)
+	}
+	if err := util.FileSystem.MkdirAll(dst, 0755); err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "create %v folder Error: %v", dst, err)
+	}
+	build, err := m.installFrom(from, base, ext, remote, dst)
+	if err != nil {
+		util.FileSystem.RemoveAll(dst)
+		return nil, err
+	}
+	build.Name = name
+	if err := util.WriteBuild(m.root, build); err != nil {
+		util.FileSystem.RemoveAll(dst)
+		return nil, err
+	}
+	if err := util.WriteSource(m.root, name, util.NewSource(from, from)); err != nil {
+		m.logger.Printf("%v", err)
+	}
+	m.logger.Printf("install %v from %v success", name, from)
+	return build, nil
+}
+
+func (m *Manager) installFrom(from, base, ext string, remote bool, dst string) (*util.Build, error) {
+	if remote {
+		m.logger.Printf("download %v to %v", from, dst)
+		if err := m.download(from, filepath.Join(dst, base), base); err != nil {
+			return nil, err
+		}
+	} else if err := util.Copy(filepath.Dir(from), dst, filepath.Base(from)); err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "copy %v to %v folder Error: %v", from, dst, err)
+	}
+
+	switch {
+	case ext == ".zip":
+		if err := util.ExtractNode(filepath.Join(dst, base), dst); err != nil {
+			return nil, err
+		}
+	case base != util.NODE:
+		if err := util.FileSystem.Rename(filepath.Join(dst, base), filepath.Join(dst, util.NODE)); err != nil {
+			return nil, util.Errorf(util.EXIT_ERROR, "rename %v Error: %v", filepath.Join(dst, base), err)
+		}
+	}
+
+	version, err := util.GetNodeVer(dst)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "%v --version fail, Error: %v", filepath.Join(dst, util.NODE), err)
+	}
+	arch, err := util.Arch(dst)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "read %v arch Error: %v", filepath.Join(dst, util.NODE), err)
+	}
+	return &util.Build{From: from, Version: version, Arch: arch}, nil
+}
+
+/*
+Return true when Node.js version is any custom build version, e.g. 18.19.0 18.19.0-x86
+*/
+func (m *Manager) isBuild(version string) bool {
+	files, err := util.FileSystem.ReadDir(m.root)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if build, err := util.ReadBuild(m.root, file.Name()); err == nil {
+			if version == build.Version || version == build.Version+"-"+build.Arch {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Global npm packages not migrate, npm is managed by gnvm npm, corepack is bundled with Node.js
+*/
+var skipGlobals = map[string]bool{util.NPM: true, "corepack": true}
+
+/*
+Global npm package of local Node.js version
+
+  - Name:    package name, e.g. typescript @vue/cli
+  - Version: installed version, e.g. 5.4.5
+*/
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+/*
+Return npm install spec, e.g. typescript@5.4.5, when latest == true, e.g. typescript@latest
+*/
+func (p Package) Spec(latest bool) string {
+	if latest || p.Version == "" {
+		return p.Name + "@" + util.LATEST
+	}
+	return p.Name + "@" + p.Version
+}
+
+/*
+Return global npm packages of local Node.js version, from <prefix>/node_modules/<name>/package.json
+
+Param:
+  - folder: e.g. x.xx.xx x.xx.xx-x86, global folder prefix is <root>, others are <root>/<folder>
+
+Return:
+  - []Package: sort by name, not include npm and corepack
+  - error:     EXIT_NOT_INSTALLED when folder is not exist
+*/
+func (m *Manager) Globals(folder string) ([]Package, error) {
+	if folder == "" || !util.IsDirExist(m.root, folder, util.NODE) {
+		return nil, util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist %v", folder, util.NODE)
+	}
+	modules := filepath.Join(m.prefix(folder), "node_modules")
+	entries, err := util.FileSystem.ReadDir(modules)
+	if err != nil {
+		return []Package{}, nil
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		switch name := entry.Name(); {
+		case !entry.IsDir() || strings.HasPrefix(name, "."):
+		case strings.HasPrefix(name, "@"):
+			scoped, _ := util.FileSystem.ReadDir(filepath.Join(modules, name))
+			for _, e := range scoped {
+				if e.IsDir() {
+					names = append(names, name+"/"+e.Name())
+				}
+			}
+		default:
+			names = append(names, name)
+		}
+	}
+
+	packages := []Package{}
+	for _, name := range names {
+		if skipGlobals[name] {
+			continue
+		}
+		pkg := Package{}
+		file, err := util.FileSystem.Open(filepath.Join(modules, filepath.FromSlash(name), "package.json"))
+		if err != nil {
+			continue
+		}
+		err = json.NewDecoder(file).Decode(&pkg)
+		file.Close()
+		if err != nil || pkg.Name == "" {
+			m.logger.Printf("skip %v, package.json is invalid", filepath.Join(modules, name))
+			continue
+		}
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Name < packages[j].Name })
+	return packages, nil
+}
+
+/*
+Install global npm packages to local Node.js version, usage npm of folder, run <root>/<folder>/node.exe npm-cli.js install -g --prefix <prefix> <packages>
+
+Param:
+  - folder:   target folder, e.g. 20.1.0, global folder prefix is <root>, others are <root>/<folder>
+  - packages: e.g. Globals of source folder
+  - latest:   when true, install latest versions, otherwise pinned versions
+
+Return:
+  - error: EXIT_NOT_INSTALLED when folder or npm is not exist
+*/
+func (m *Manager) InstallGlobals(folder string, packages []Package, latest bool) error {
+	if folder == "" || !util.IsDirExist(m.root, folder, util.NODE) {
+		return util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist %v", folder, util.NODE)
+	}
+	if len(packages) == 0 {
+		return nil
+	}
+	cli := m.npmCLI(folder)
+	if cli == "" {
+		return util.Errorf(util.EXIT_NOT_INSTALLED, "not found npm of %v", folder)
+	}
+
+	node, args := filepath.Join(m.root, folder, util.NODE), []string{cli, "install", "-g", "--prefix", m.prefix(folder)}
+	for _, pkg := range packages {
+		args = append(args, pkg.Spec(latest))
+	}
+	m.logger.Printf("run %v %v", node, strings.Join(args, " "))
+	if out, err := util.Runner.Output(node, args...); err != nil {
+		return util.Errorf(util.EXIT_ERROR, "npm install -g Error: %v %v", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+/*
+Return npm global prefix of folder, global folder is <root>, others are <root>/<folder>
+*/
+func (m *Manager) prefix(folder string) string {
+	if global, err := m.global(); err == nil && global == folder {
+		return m.root
+	}
+	return filepath.Join(m.root, folder)
+}
+
+/*
+Return npm-cli.js of folder, <root>/<folder>/node_modules/npm first, then <root>/node_modules/npm, when not found, return ""
+*/
+func (m *Manager) npmCLI(folder string) string {
+	for _, dir := range []string{filepath.Join(m.root, folder), m.root} {
+		if cli := filepath.Join(dir, "node_modules", util.NPM, "bin", "npm-cli.js"); util.IsDirExist(cli) {
+			return cli
+		}
+	}
+	return ""
+}
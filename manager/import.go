@@ -0,0 +1,279 @@
+package manager
+
+import (
+	// go
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Other Node.js version manager, usage gnvm import --from, include:
+  - IMPORT_NVM_WINDOWS: %NVM_HOME%\v<version>\node.exe, default is %NVM_SYMLINK% link
+  - IMPORT_NVM:         ~/.nvm/versions/node/v<version>/bin/node, default is ~/.nvm/alias/default
+  - IMPORT_NODIST:      %NODIST_PREFIX%\v\<version>\node.exe and v-x64\<version>\node.exe, default is .node-version-global
+  - IMPORT_FNM:         %FNM_DIR%\node-versions\v<version>\installation\node.exe, default is aliases\default link
+*/
+const (
+	IMPORT_NVM_WINDOWS = "nvm-windows"
+	IMPORT_NVM         = "nvm"
+	IMPORT_NODIST      = "nodist"
+	IMPORT_FNM         = "fnm"
+)
+
+/*
+Import mode, include:
+  - IMPORT_COPY: copy node.exe to <root>/<folder>
+  - IMPORT_MOVE: move node.exe to <root>/<folder>
+  - IMPORT_LINK: hard link node.exe to <root>/<folder>, when fail, usage symbolic link
+*/
+const (
+	IMPORT_COPY = "copy"
+	IMPORT_MOVE = "move"
+	IMPORT_LINK = "link"
+)
+
+var IMPORT_TOOLS = []string{IMPORT_NVM_WINDOWS, IMPORT_NVM, IMPORT_NODIST, IMPORT_FNM}
+
+var IMPORT_MODES = []string{IMPORT_COPY, IMPORT_MOVE, IMPORT_LINK}
+
+var (
+	foreignReg = regexp.MustCompile(`^v?(\d+\.\d+\.\d+)$`)
+	aliasReg   = regexp.MustCompile(`v?(\d+\.\d+\.\d+)`)
+)
+
+/*
+Node.js version installed by other version manager
+
+  - Version: x.xx.xx
+  - Arch:    include: "x86" "x64"
+  - Exec:    node executable path, e.g. C:\nvm\v18.19.0\node.exe
+  - Default: true when it is default / alias version of version manager
+*/
+type Foreign struct {
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	Exec    string `json:"exec"`
+	Default bool   `json:"default"`
+}
+
+/*
+Return local folder name, e.g. x.xx.xx x.xx.xx-x86
+*/
+func (f Foreign) Folder() string {
+	return util.VersionFolder(f.Version, f.Arch)
+}
+
+/*
+Return default install path of version manager from environment variables and home folder
+
+Param:
+  - tool: include: IMPORT_NVM_WINDOWS IMPORT_NVM IMPORT_NODIST IMPORT_FNM
+
+Return:
+  - path: when not found, return ""
+*/
+func ImportPath(tool string) string {
+	home, _ := os.UserHomeDir()
+	switch tool {
+	case IMPORT_NVM_WINDOWS:
+		return os.Getenv("NVM_HOME")
+	case IMPORT_NVM:
+		if dir := os.Getenv("NVM_DIR"); dir != "" {
+			return dir
+		}
+		return filepath.Join(home, ".nvm")
+	case IMPORT_NODIST:
+		return os.Getenv("NODIST_PREFIX")
+	case IMPORT_FNM:
+		if dir := os.Getenv("FNM_DIR"); dir != "" {
+			return dir
+		}
+		if dir := os.Getenv("APPDATA"); dir != "" && util.IsDirExist(dir, "fnm") {
+			return filepath.Join(dir, "fnm")
+		}
+		return filepath.Join(home, ".local", "share", "fnm")
+	}
+	return ""
+}
+
+/*
+Scan Node.js versions installed by other version manager, sort by version desc
+
+Param:
+  - tool: see ImportPath
+  - dir:  version manager install path, e.g. C:\nvm
+
+Return:
+  - []Foreign
+  - error: tool not support or dir not exist
+*/
+func Scan(tool, dir string) ([]Foreign, error) {
+	if dir == "" || !util.IsDirExist(dir) {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v folder %v is not exist", tool, dir)
+	}
+	var foreigns []Foreign
+	switch tool {
+	case IMPORT_NVM_WINDOWS:
+		foreigns = scanFolder(dir, "", util.NODE)
+		markDefault(foreigns, readLink(os.Getenv("NVM_SYMLINK")))
+	case IMPORT_NVM:
+		foreigns = scanFolder(filepath.Join(dir, "versions", "node"), "", "bin/node", util.NODE)
+		markDefault(foreigns, readFile(filepath.Join(dir, "alias", "default")))
+	case IMPORT_NODIST:
+		foreigns = append(scanFolder(filepath.Join(dir, "v-x64"), "x64", util.NODE), scanFolder(filepath.Join(dir, "v"), "x86", util.NODE)...)
+		markDefault(foreigns, readFile(filepath.Join(dir, ".node-version-global")))
+	case IMPORT_FNM:
+		foreigns = scanFolder(filepath.Join(dir, "node-versions"), "", "installation/"+util.NODE, "installation/bin/node")
+		markDefault(foreigns, readLink(filepath.Join(dir, "aliases", "default")))
+	default:
+		return nil, util.Errorf(util.EXIT_USAGE, "%v not support, include: %v", tool, strings.Join(IMPORT_TOOLS, ", "))
+	}
+	sort.SliceStable(foreigns, func(i, j int) bool {
+		return util.FormatNodeVer(foreigns[i].Version) > util.FormatNodeVer(foreigns[j].Version)
+	})
+	return foreigns, nil
+}
+
+/*
+Scan <dir>/<version>/<exec>, first exist exec is node executable
+
+Param:
+  - dir:   e.g. ~/.nvm/versions/node
+  - arch:  include: "x86" "x64", when arch == "", usage util.Arch
+  - execs: relative path, e.g. bin/node node.exe
+*/
+func scanFolder(dir, arch string, execs ...string) []Foreign {
+	files, err := util.FileSystem.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	foreigns := []Foreign{}
+	for _, file := range files {
+		arr := foreignReg.FindStringSubmatch(file.Name())
+		if !file.IsDir() || arr == nil {
+			continue
+		}
+		for _, exec := range execs {
+			path := filepath.Join(dir, file.Name(), filepath.FromSlash(exec))
+			if !util.IsDirExist(path) {
+				continue
+			}
+			a := arch
+			if a == "" {
+				a = "x64"
+				if filepath.Base(path) == util.NODE {
+					a, _ = util.Arch(filepath.Dir(path))
+				}
+			}
+			foreigns = append(foreigns, Foreign{arr[1], a, path, false})
+			break
+		}
+	}
+	return foreigns
+}
+
+/*
+Mark default version, alias support x.xx.xx vx.xx.xx x.xx x and path which include version, e.g. node-versions/v18.19.0/installation
+*/
+func markDefault(foreigns []Foreign, alias string) {
+	alias = strings.TrimPrefix(strings.TrimSpace(alias), "v")
+	if alias == "" {
+		return
+	}
+	if arr := aliasReg.FindStringSubmatch(alias); arr != nil {
+		alias = arr[1]
+	}
+	// the first matching version of desc order, e.g. alias 18 match 18.19.0
+	best := -1
+	for i, f := range foreigns {
+		if f.Version == alias || strings.HasPrefix(f.Version, alias+".") {
+			if best == -1 || util.FormatNodeVer(f.Version) > util.FormatNodeVer(foreigns[best].Version) {
+				best = i
+			}
+		}
+	}
+	if best != -1 {
+		foreigns[best].Default = true
+	}
+}
+
+func readFile(path string) string {
+	file, err := util.FileSystem.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	buf := make([]byte, 256)
+	n, _ := file.Read(buf)
+	return strings.TrimSpace(string(buf[:n]))
+}
+
+func readLink(path string) string {
+	if path == "" {
+		return ""
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(target)
+}
+
+/*
+Import Node.js version of other version manager to <root>/<folder>/node.exe
+
+Param:
+  - f:    Foreign, usage Scan
+  - mode: include: IMPORT_COPY IMPORT_MOVE IMPORT_LINK
+
+Return:
+  - folder: e.g. x.xx.xx x.xx.xx-x86, when folder exist, not import again
+  - error
+*/
+func (m *Manager) Import(f Foreign, mode string) (string, error) {
+	folder := f.Folder()
+	dst := filepath.Join(m.root, folder)
+	if util.IsDirExist(dst, util.NODE) {
+		m.logger.Printf("%v folder exist", folder)
+		return folder, nil
+	}
+	if err := util.FileSystem.MkdirAll(dst, 0755); err != nil {
+		return "", util.Errorf(util.EXIT_ERROR, "create %v folder Error: %v", dst, err)
+	}
+
+	var err error
+	target := filepath.Join(dst, util.NODE)
+	switch mode {
+	case IMPORT_COPY:
+		if err = util.Copy(filepath.Dir(f.Exec), dst, filepath.Base(f.Exec)); err == nil && filepath.Base(f.Exec) != util.NODE {
+			err = util.FileSystem.Rename(filepath.Join(dst, filepath.Base(f.Exec)), target)
+		}
+	case IMPORT_MOVE:
+		err = util.FileSystem.Rename(f.Exec, target)
+	case IMPORT_LINK:
+		err = util.FileSystem.Link(f.Exec, target)
+	default:
+		util.FileSystem.RemoveAll(dst)
+		return "", util.Errorf(util.EXIT_USAGE, "%v not support, include: %v", mode, strings.Join(IMPORT_MODES, ", "))
+	}
+	if err != nil {
+		util.FileSystem.RemoveAll(dst)
+		return "", util.Errorf(util.EXIT_ERROR, "%v %v to %v Error: %v", mode, f.Exec, dst, err)
+	}
+
+	if _, err := util.GetNodeVer(dst); err != nil {
+		if mode == IMPORT_MOVE {
+			util.FileSystem.Rename(target, f.Exec)
+		}
+		util.FileSystem.RemoveAll(dst)
+		return "", util.Errorf(util.EXIT_ERROR, "%v --version fail, Error: %v", target, err)
+	}
+	m.logger.Printf("%v %v to %v success", mode, f.Exec, dst)
+	return folder, nil
+}
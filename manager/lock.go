@@ -0,0 +1,236 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Lockfile name, usage gnvm export
+*/
+const LOCKFILE = "gnvm-lock.json"
+
+/*
+Lockfile of installed Node.js versions, e.g. gnvm-lock.json
+
+  - Global:   global Node.js version folder
+  - Latest:   latest Node.js version, e.g. latestversion of .gnvmrc
+  - NPM:      global npm version
+  - Versions: installed Node.js versions, sort by folder
+*/
+type Lock struct {
+	Global   string   `json:"global"`
+	Latest   string   `json:"latest"`
+	NPM      string   `json:"npm"`
+	Versions []Locked `json:"versions"`
+}
+
+/*
+Locked Node.js version
+
+  - Version:  see Local
+  - Folder:   folder name, e.g. x.xx.xx-x86
+  - Arch:     include: "x86" "x64"
+  - Registry: install registry or custom build source, when unknown, e.g. gnvm import, it is ""
+  - SHA256:   SHA-256 of all files, key is relative path, e.g. node.exe
+*/
+type Locked struct {
+	Version  string            `json:"version"`
+	Folder   string            `json:"folder"`
+	Arch     string            `json:"arch"`
+	Registry string            `json:"registry"`
+	SHA256   map[string]string `json:"sha256"`
+}
+
+/*
+Lockfile difference, usage CompareLock
+
+  - Folder: Node.js version folder, when it is global, latest or npm, Folder is ""
+  - Field:  include: missing extra arch sha256:<file> global latest npm
+  - Want:   lockfile value
+  - Got:    current value
+*/
+type Drift struct {
+	Folder string `json:"folder"`
+	Field  string `json:"field"`
+	Want   string `json:"want"`
+	Got    string `json:"got"`
+}
+
+/*
+Create lockfile of installed Node.js versions, Latest and NPM not include, because Manager not read .gnvmrc
+
+Return:
+  - *Lock
+  - error
+*/
+func (m *Manager) Lock() (*Lock, error) {
+	locals, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	lock := &Lock{Versions: []Locked{}}
+	for _, local := range locals {
+		sums, err := m.Hash(local.Folder)
+		if err != nil {
+			return nil, err
+		}
+		if local.Global {
+			lock.Global = local.Folder
+		}
+		lock.Versions = append(lock.Versions, Locked{local.Version, local.Folder, local.Arch, m.source(local.Folder), sums})
+	}
+	sort.Slice(lock.Versions, func(i, j int) bool { return lock.Versions[i].Folder < lock.Versions[j].Folder })
+	return lock, nil
+}
+
+/*
+Return SHA-256 of all files in <root>/<folder>, gnvm marker files not include, e.g. gnvm-source.json
+
+Param:
+  - folder: e.g. x.xx.xx
+
+Return:
+  - map: key is relative path with '/', e.g. node.exe
+  - error
+*/
+func (m *Manager) Hash(folder string) (map[string]string, error) {
+	dir, sums := filepath.Join(m.root, folder), map[string]string{}
+	if err := hashDir(dir, "", sums); err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "hash %v Error: %v", dir, err)
+	}
+	return sums, nil
+}
+
+func hashDir(dir, rel string, sums map[string]string) error {
+	files, err := util.FileSystem.ReadDir(filepath.Join(dir, rel))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		name := path.Join(rel, file.Name())
+		if file.IsDir() {
+			if err := hashDir(dir, name, sums); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(file.Name(), "gnvm-") && path.Ext(file.Name()) == ".json" {
+			continue
+		}
+		sum, err := util.HashFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		sums[name] = sum
+	}
+	return nil
+}
+
+/*
+Read lockfile
+
+Return:
+  - *Lock
+  - error: EXIT_USAGE when lockfile not exist or invalid
+*/
+func ReadLock(path string) (*Lock, error) {
+	file, err := util.FileSystem.Open(path)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v", err)
+	}
+	defer file.Close()
+	lock := new(Lock)
+	if err := json.NewDecoder(file).Decode(lock); err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "parse %v Error: %v", path, err)
+	}
+	return lock, nil
+}
+
+/*
+Write lockfile, json indent is 2 spaces
+*/
+func WriteLock(path string, lock *Lock) error {
+	file, err := util.FileSystem.Create(path)
+	if err != nil {
+		return util.Errorf(util.EXIT_ERROR, "create %v Error: %v", path, err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(lock)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return util.Errorf(util.EXIT_ERROR, "write %v Error: %v", path, err)
+	}
+	return nil
+}
+
+/*
+Compare lockfile and current machine, Registry not compare, same binaries maybe download from mirror
+
+Param:
+  - want: lockfile, usage ReadLock
+  - got:  current machine, usage Lock
+
+Return:
+  - []Drift: when len == 0, current machine is the same as lockfile
+*/
+func CompareLock(want, got *Lock) []Drift {
+	drifts, locals := []Drift{}, map[string]Locked{}
+	for _, v := range got.Versions {
+		locals[v.Folder] = v
+	}
+	for _, w := range want.Versions {
+		g, ok := locals[w.Folder]
+		if !ok {
+			drifts = append(drifts, Drift{w.Folder, "missing", w.Folder, ""})
+			continue
+		}
+		delete(locals, w.Folder)
+		if w.Arch != g.Arch {
+			drifts = append(drifts, Drift{w.Folder, "arch", w.Arch, g.Arch})
+		}
+		for _, name := range unionKeys(w.SHA256, g.SHA256) {
+			if w.SHA256[name] != g.SHA256[name] {
+				drifts = append(drifts, Drift{w.Folder, "sha256:" + name, w.SHA256[name], g.SHA256[name]})
+			}
+		}
+	}
+	extras := []string{}
+	for folder := range locals {
+		extras = append(extras, folder)
+	}
+	sort.Strings(extras)
+	for _, folder := range extras {
+		drifts = append(drifts, Drift{folder, "extra", "", folder})
+	}
+	for _, v := range [][3]string{{"global", want.Global, got.Global}, {"latest", want.Latest, got.Latest}, {"npm", want.NPM, got.NPM}} {
+		if v[1] != v[2] {
+			drifts = append(drifts, Drift{"", v[0], v[1], v[2]})
+		}
+	}
+	return drifts
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := []string{}
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,669 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Logger, e.g. *log.Logger
+*/
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+/*
+Manager options
+
+  - Root:        gnvm root path, include node.exe and x.xx.xx folders, required
+  - Registry:    Node.js registry, default util.ORIGIN_DEFAULT
+  - NPMRegistry: npm registry, default NPM_REGISTRY
+  - Channels:    Node.js release channel registry, key is channel name, e.g. nightly: https://nodejs.org/download/nightly/
+  - Schedule:    Node.js release schedule folder, usage <Schedule>schedule.json, default util.ORIGIN_RELEASE
+  - Client:      http client, default util.HTTPClient
+  - Logger:      progress logger, default discard
+  - Progress:    download progress, name is Node.js folder or file name, total is -1 when unknown, default nil
+*/
+type Options struct {
+	Root        string
+	Registry    string
+	NPMRegistry string
+	Channels    map[string]string
+	Schedule    string
+	Client      *http.Client
+	Logger      Logger
+	Progress    func(name string, done, total int64)
+}
+
+/*
+Node.js version manager, not read .gnvmrc and not print, all result return values and errors
+
+File system and process execution usage util.FileSystem and util.Runner
+*/
+type Manager struct {
+	root        string
+	registry    string
+	npmRegistry string
+	channels    map[string]string
+	schedule    string
+	client      *http.Client
+	logger      Logger
+	progress    func(name string, done, total int64)
+}
+
+/*
+Local Node.js version
+
+  - Version: x.xx.xx, channel version and custom build is folder name, e.g. rc@22.0.0-rc.1 18.19.0-patched
+  - Folder:  folder name, e.g. x.xx.xx-x86
+  - Arch:    include: "x86" "x64"
+  - Global:  true when <root>/node.exe is this version
+  - Path:    folder path
+*/
+type Local struct {
+	Version string `json:"version"`
+	Folder  string `json:"folder"`
+	Arch    string `json:"arch"`
+	Global  bool   `json:"global"`
+	Path    string `json:"path"`
+}
+
+/*
+Remote Node.js version, from <registry>/index.json
+*/
+type Remote struct {
+	Version  string      `json:"version"`
+	Date     string      `json:"date"`
+	Files    []string    `json:"files"`
+	NPM      string      `json:"npm"`
+	V8       string      `json:"v8"`
+	UV       string      `json:"uv"`
+	Zlib     string      `json:"zlib"`
+	OpenSSL  string      `json:"openssl"`
+	Modules  string      `json:"modules"`
+	LTS      interface{} `json:"lts"`
+	Security bool        `json:"security"`
+}
+
+/*
+Create Manager
+
+Param:
+  - opts: Manager options, Root is required
+
+Return:
+  - *Manager
+  - error
+*/
+func New(opts Options) (*Manager, error) {
+	if opts.Root == "" {
+		return nil, util.Errorf(util.EXIT_USAGE, "manager root is required")
+	}
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "manager root %v, Error: %v", opts.Root, err)
+	}
+	if opts.Registry == "" {
+		opts.Registry = util.ORIGIN_DEFAULT
+	}
+	if !strings.HasSuffix(opts.Registry, "/") {
+		opts.Registry += "/"
+	}
+	if opts.NPMRegistry == "" {
+		opts.NPMRegistry = NPM_REGISTRY
+	}
+	if !strings.HasSuffix(opts.NPMRegistry, "/") {
+		opts.NPMRegistry += "/"
+	}
+	channels := make(map[string]string, len(opts.Channels))
+	for name, url := range opts.Channels {
+		if !strings.HasSuffix(url, "/") {
+			url += "/"
+		}
+		channels[name] = url
+	}
+	if opts.Schedule == "" {
+		opts.Schedule = util.ORIGIN_RELEASE
+	}
+	if !strings.HasSuffix(opts.Schedule, "/") {
+		opts.Schedule += "/"
+	}
+	if opts.Client == nil {
+		opts.Client = util.HTTPClient
+	}
+	if opts.Logger == nil {
+		opts.Logger = log.New(io.Discard, "", 0)
+	}
+	return &Manager{root, opts.Registry, opts.NPMRegistry, channels, opts.Schedule, opts.Client, opts.Logger, opts.Progress}, nil
+}
+
+/*
+Return gnvm root path
+*/
+func (m *Manager) Root() string {
+	return m.root
+}
+
+/*
+Return Node.js registry
+*/
+func (m *Manager) Registry() string {
+	return m.registry
+}
+
+/*
+Resolve version to local folder name
+
+Param:
+  - spec: include: latest latest-x86|x64 global x.xx.xx x.xx.xx-x86|x64 *.*.* x.*.* x.xx.* /<regexp>/ <channel> <channel>/x.xx.xx-<tag>
+
+Return:
+  - folder: e.g. x.xx.xx x.xx.xx-x86 nightly@22.0.0-nightly20240101abcdef
+  - error:  *util.VersionError when spec format error
+*/
+func (m *Manager) Resolve(spec string) (string, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if channel, version, suffix, ok := util.ParseChannel(spec); ok {
+		return m.resolveChannel(channel, version, suffix)
+	}
+	switch {
+	case spec == util.GLOBAL:
+		folder, err := m.global()
+		if err != nil {
+			return "", util.Errorf(util.EXIT_NOT_INSTALLED, "not found global %v in %v", util.NODE, m.root)
+		}
+		return folder, nil
+	case spec == util.LATEST || spec == util.LATEST+"-x86" || spec == util.LATEST+"-x64":
+		remotes, err := m.ListRemote()
+		if err != nil {
+			return "", err
+		}
+		if len(remotes) == 0 {
+			return "", util.Errorf(util.EXIT_NETWORK, "%v not any Node.js version", m.registry+util.NODELIST)
+		}
+		return remotes[0].Version[1:] + strings.TrimPrefix(spec, util.LATEST), nil
+	case strings.ContainsAny(strings.Split(spec, "-")[0], "*x/"):
+		filter, err := util.FormatWildcard(spec, "")
+		if err != nil {
+			return "", err
+		}
+		remotes, err := m.ListRemote()
+		if err != nil {
+			return "", err
+		}
+		for _, r := range remotes {
+			if filter.MatchString(r.Version[1:]) {
+				return r.Version[1:], nil
+			}
+		}
+		return "", util.Errorf(util.EXIT_NOT_INSTALLED, "not found any Node.js version matching %v from %v", spec, m.registry)
+	}
+	if _, _, _, _, err := util.ParseNodeVer(spec); err != nil {
+		return "", err
+	}
+	return spec, nil
+}
+
+/*
+Resolve channel version to local folder name, e.g. nightly to nightly@22.0.0-nightly20240101abcdef
+*/
+func (m *Manager) resolveChannel(channel, version, suffix string) (string, error) {
+	registry, err := m.Channel(channel, false)
+	if err != nil {
+		return "", err
+	}
+	if version == util.LATEST {
+		remotes, err := m.listRemote(registry)
+		if err != nil {
+			return "", err
+		}
+		if len(remotes) == 0 {
+			return "", util.Errorf(util.EXIT_NETWORK, "%v not any Node.js version", registry+util.NODELIST)
+		}
+		version = remotes[0].Version[1:]
+	}
+	if suffix != "" {
+		version += "-" + suffix
+	}
+	return util.ChannelFolder(channel + "/" + version), nil
+}
+
+/*
+Return registry of release channel or io.js, usage install, ls -r, search and index.json
+
+Param:
+  - name: channel name, include: "" release rc nightly test <custom channel>
+  - io:   true when io.js version, only usage with release channel
+
+Return:
+  - registry: e.g. https://nodejs.org/dist/ https://nodejs.org/download/rc/ https://iojs.org/dist/
+  - error:    EXIT_USAGE when channel not configured
+*/
+func (m *Manager) Channel(name string, io bool) (string, error) {
+	if name == "" || name == util.CHANNEL_RELEASE {
+		if io {
+			return ioRegistry(m.registry), nil
+		}
+		return m.registry, nil
+	}
+	registry, ok := m.channels[name]
+	if !ok {
+		return "", util.Errorf(util.EXIT_USAGE, "channel %v not configured of registry %v, please use 'gnvm config channel.%v <url>'", name, m.registry, name)
+	}
+	return registry, nil
+}
+
+/*
+Download and install Node.js version to <root>/<folder>/node.exe
+
+Param:
+  - spec: see Resolve
+
+Return:
+  - folder: installed folder name, when folder exist, not download again
+  - error
+*/
+func (m *Manager) Install(spec string) (string, error) {
+	folder, err := m.Resolve(spec)
+	if err != nil {
+		return "", err
+	}
+	ver, io, arch, _, err := util.ParseNodeVer(folder)
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOARCH == "386" && arch == "amd64" {
+		return "", util.Errorf(util.EXIT_USAGE, "current operating system is 32-bit, not support x64 suffix")
+	}
+
+	dst := filepath.Join(m.root, folder)
+	if util.IsDirExist(dst, util.NODE) {
+		m.logger.Printf("%v folder exist", folder)
+		return folder, nil
+	}
+
+	registry, url, kind, err := m.remote(ver, io, arch)
+	if err != nil {
+		return "", err
+	}
+
+	m.logger.Printf("download %v from %v", folder, url)
+	if err := util.FileSystem.MkdirAll(dst, 0755); err != nil {
+		return "", util.Errorf(util.EXIT_ERROR, "create %v folder Error: %v", dst, err)
+	}
+	name := util.NODE
+	if kind == util.FILE_ZIP {
+		name = path.Base(url)
+	}
+	if err := m.download(url, filepath.Join(dst, name), folder); err != nil {
+		util.FileSystem.RemoveAll(dst)
+		return "", err
+	}
+	if kind == util.FILE_ZIP {
+		if err := util.ExtractNode(filepath.Join(dst, name), dst); err != nil {
+			util.FileSystem.RemoveAll(dst)
+			return "", err
+		}
+	}
+	if err := util.WriteSource(m.root, folder, util.NewSource(registry, url)); err != nil {
+		m.logger.Printf("%v", err)
+	}
+	return folder, nil
+}
+
+/*
+Return registry and download url of Node.js version
+
+Param:
+  - ver:  x.xx.xx or <channel>@<version>, see util.ParseNodeVer
+  - io:   true when it is io.js version
+  - arch: include: "386" "amd64" "arm64"
+
+Return:
+  - registry: e.g. https://nodejs.org/dist/ https://nodejs.org/download/rc/
+  - url:      e.g. https://nodejs.org/dist/v20.1.0/win-x64/node.exe
+  - kind:     include: FILE_EXE FILE_ZIP
+  - error
+*/
+func (m *Manager) remote(ver string, io bool, arch string) (registry, url, kind string, err error) {
+	channel := ""
+	if c, version, _, ok := util.ParseChannel(ver); ok {
+		channel, ver, io = c, version, false
+	}
+	if registry, err = m.Channel(channel, io); err != nil {
+		return
+	}
+	if url, kind, err = util.GetRemoteNodeBuild(registry, ver, arch, m.files(registry, ver)); err != nil {
+		err = util.Errorf(util.EXIT_NOT_INSTALLED, "%v", err)
+	}
+	return
+}
+
+/*
+Return index.json files of Node.js version, when index.json not found or not include version, return nil
+
+Param:
+  - registry: e.g. https://nodejs.org/dist/
+  - ver:      x.xx.xx x.xx.xx-<tag>
+*/
+func (m *Manager) files(registry, ver string) []string {
+	remotes, err := m.listRemote(registry)
+	if err != nil {
+		m.logger.Printf("%v, usage default download url", err)
+		return nil
+	}
+	for _, r := range remotes {
+		if r.Version == "v"+ver && r.Files != nil {
+			return r.Files
+		}
+	}
+	return nil
+}
+
+/*
+Remove <root>/<folder>
+
+Param:
+  - spec: see Resolve, not support latest and wildcard
+
+Return:
+  - error
+*/
+func (m *Manager) Uninstall(spec string) error {
+	folder := strings.ToLower(strings.TrimSpace(spec))
+	if !util.VerifyNodeVer(folder) || folder == util.LATEST || folder == util.UNKNOWN {
+		return &util.VersionError{Input: spec, Err: util.ErrInvalidVersion}
+	}
+	if folder == util.GLOBAL {
+		return util.Errorf(util.EXIT_USAGE, "not support uninstall %v Node.js version", util.GLOBAL)
+	}
+	path := filepath.Join(m.root, folder)
+	if !util.IsDirExist(path) {
+		return util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist", folder)
+	}
+	if err := util.FileSystem.RemoveAll(path); err != nil {
+		return util.Errorf(util.EXIT_ERROR, "uninstall %v fail, Error: %v", folder, err)
+	}
+	m.logger.Printf("Node.js version %v uninstall success", folder)
+	return nil
+}
+
+/*
+Copy <root>/<folder>/node.exe to <root>/node.exe, old global node.exe backup to <root>/<global>/node.exe
+
+Param:
+  - spec: see Resolve, not support latest and wildcard
+
+Return:
+  - folder: new global folder name
+  - error
+*/
+func (m *Manager) Use(spec string) (string, error) {
+	folder := strings.ToLower(strings.TrimSpace(spec))
+	if folder == util.LATEST || strings.Contains(folder, "*") || !util.VerifyNodeVer(folder) {
+		return "", &util.VersionError{Input: spec, Err: util.ErrInvalidVersion}
+	}
+	newerPath := filepath.Join(m.root, folder)
+	if !util.IsDirExist(newerPath, util.NODE) {
+		return "", util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist %v", folder, util.NODE)
+	}
+
+	global, err := m.global()
+	if err == nil && global == folder {
+		m.used(folder)
+		return folder, nil
+	}
+
+	// backup <root>/node.exe to <root>/<global>/node.exe, channel version and custom build not backup, e.g. 22.0.0-rc.1
+	if _, _, _, _, e := util.ParseNodeVer(global); err == nil && e == nil && !m.isBuild(global) {
+		globalPath := filepath.Join(m.root, global)
+		if !util.IsDirExist(globalPath, util.NODE) {
+			if err := util.FileSystem.MkdirAll(globalPath, 0755); err != nil {
+				return "", util.Errorf(util.EXIT_ERROR, "create %v folder Error: %v", globalPath, err)
+			}
+			if err := util.Copy(m.root, globalPath, util.NODE); err != nil {
+				return "", util.Errorf(util.EXIT_ERROR, "copy %v to %v folder Error: %v", m.root, globalPath, err)
+			}
+		}
+	}
+
+	if err := util.Copy(newerPath, m.root, util.NODE); err != nil {
+		return "", util.Errorf(util.EXIT_ERROR, "copy %v to %v folder Error: %v", newerPath, m.root, err)
+	}
+	m.used(folder)
+	m.logger.Printf("set success, global Node.js version is %v", folder)
+	return folder, nil
+}
+
+/*
+Record last used time of folder, usage gnvm prune --unused-since
+*/
+func (m *Manager) used(folder string) {
+	if err := util.WriteUsed(m.root, folder); err != nil {
+		m.logger.Printf("%v", err)
+	}
+}
+
+/*
+List local Node.js versions, sort by folder name
+
+Return:
+  - []Local
+  - error
+*/
+func (m *Manager) List() ([]Local, error) {
+	files, err := util.FileSystem.ReadDir(m.root)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "read %v Error: %v", m.root, err)
+	}
+	global, _ := m.global()
+	locals := []Local{}
+	for _, file := range files {
+		folder := file.Name()
+		if !file.IsDir() || !util.VerifyNodeVer(folder) || !util.IsDirExist(m.root, folder, util.NODE) {
+			continue
+		}
+		ver, _, arch, _, err := util.ParseNodeVer(folder)
+		if err != nil {
+			// custom build, e.g. 18.19.0-patched
+			build, err := util.ReadBuild(m.root, folder)
+			if err != nil {
+				continue
+			}
+			ver, arch = folder, build.Arch
+		}
+		locals = append(locals, Local{ver, folder, util.FormatArch(arch), folder == global, filepath.Join(m.root, folder)})
+	}
+	return locals, nil
+}
+
+/*
+Local Node.js version metadata, usage gnvm ls -L
+
+  - Size:      size on disk, bytes
+  - Installed: install date, from gnvm-source.json, otherwise node.exe modified time
+  - Used:      last used time by gnvm use, zero when never used
+  - Registry:  install registry or custom build source, when unknown, e.g. gnvm import, it is ""
+*/
+type Meta struct {
+	Size      int64     `json:"size"`
+	Installed time.Time `json:"installed"`
+	Used      time.Time `json:"used"`
+	Registry  string    `json:"registry"`
+}
+
+/*
+Return metadata of <root>/<folder>
+*/
+func (m *Manager) Meta(folder string) Meta {
+	meta := Meta{Installed: m.installed(folder), Registry: m.source(folder)}
+	meta.Size, _ = util.DirSize(filepath.Join(m.root, folder))
+	meta.Used, _ = util.ReadUsed(m.root, folder)
+	return meta
+}
+
+/*
+Return install date of folder, from gnvm-source.json, otherwise node.exe modified time
+*/
+func (m *Manager) installed(folder string) time.Time {
+	if source, err := util.ReadSource(m.root, folder); err == nil {
+		if t, err := time.Parse(time.RFC3339, source.Date); err == nil {
+			return t
+		}
+	}
+	if info, err := util.FileSystem.Stat(filepath.Join(m.root, folder, util.NODE)); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+/*
+Return install registry of folder, custom build is gnvm-build.json from, when unknown, return ""
+*/
+func (m *Manager) source(folder string) string {
+	if source, err := util.ReadSource(m.root, folder); err == nil {
+		return source.Registry
+	}
+	if build, err := util.ReadBuild(m.root, folder); err == nil {
+		return build.From
+	}
+	return ""
+}
+
+/*
+List remote Node.js versions from <registry>/index.json, sort by registry( version desc )
+
+Return:
+  - []Remote
+  - error
+*/
+func (m *Manager) ListRemote() ([]Remote, error) {
+	return m.listRemote(m.registry)
+}
+
+func (m *Manager) listRemote(registry string) ([]Remote, error) {
+	res, err := m.get(registry + util.NODELIST)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	remotes := []Remote{}
+	if err := json.NewDecoder(res.Body).Decode(&remotes); err != nil {
+		return nil, util.Errorf(util.EXIT_NETWORK, "parse %v Error: %v", registry+util.NODELIST, err)
+	}
+	return remotes, nil
+}
+
+/*
+Return <root>/node.exe folder name, e.g. x.xx.xx x.xx.xx-x86
+*/
+func (m *Manager) global() (string, error) {
+	ver, err := util.GetNodeVer(m.root)
+	if err != nil {
+		return "", err
+	}
+	if bit, err := util.Arch(m.root); err == nil && bit == "x86" && runtime.GOARCH == "amd64" {
+		ver += "-" + bit
+	}
+	return ver, nil
+}
+
+/*
+Http get, when response code != 200, return error
+*/
+func (m *Manager) get(url string) (*http.Response, error) {
+	res, err := m.client.Get(url)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_NETWORK, "get %v Error: %v", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, util.Errorf(util.EXIT_NETWORK, "get %v response code is %v", url, res.StatusCode)
+	}
+	return res, nil
+}
+
+/*
+Download url to dst, write <dst>.download first, rename when finish, name is Progress name
+*/
+func (m *Manager) download(url, dst, name string) error {
+	res, err := m.get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	tmp := dst + ".download"
+	file, err := util.FileSystem.Create(tmp)
+	if err != nil {
+		return util.Errorf(util.EXIT_ERROR, "create %v Error: %v", tmp, err)
+	}
+	var body io.Reader = res.Body
+	if m.progress != nil {
+		body = &progressReader{Reader: res.Body, name: name, total: res.ContentLength, fn: m.progress}
+	}
+	n, err := io.Copy(file, body)
+	file.Close()
+	if err == nil && res.ContentLength > 0 && n != res.ContentLength {
+		err = util.Errorf(util.EXIT_CHECKSUM, "download %v size %v, but content length is %v", url, n, res.ContentLength)
+	}
+	if err != nil {
+		util.FileSystem.Remove(tmp)
+		if util.ExitCode(err) == util.EXIT_ERROR {
+			err = util.Errorf(util.EXIT_NETWORK, "download %v Error: %v", url, err)
+		}
+		return err
+	}
+	if err := util.FileSystem.Rename(tmp, dst); err != nil {
+		return util.Errorf(util.EXIT_ERROR, "rename %v Error: %v", tmp, err)
+	}
+	return nil
+}
+
+/*
+Return io.js registry, e.g. https://nodejs.org/dist/ to https://iojs.org/dist/
+*/
+func ioRegistry(url string) string {
+	if url == util.ORIGIN_TAOBAO {
+		return strings.Replace(url, "/node", "/iojs", -1)
+	} else if url == util.ORIGIN_DEFAULT {
+		return strings.Replace(url, "nodejs.org", "iojs.org", -1)
+	}
+	return url
+}
+
+/*
+Report download progress when percent changed or finish
+*/
+type progressReader struct {
+	io.Reader
+	name        string
+	done, total int64
+	percent     int64
+	fn          func(name string, done, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.done += int64(n)
+	switch {
+	case err == io.EOF && r.percent != 100:
+		r.fn(r.name, r.done, r.total)
+	case r.total > 0 && r.done*100/r.total != r.percent:
+		r.percent = r.done * 100 / r.total
+		r.fn(r.name, r.done, r.total)
+	}
+	return n, err
+}
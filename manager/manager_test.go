@@ -0,0 +1,894 @@
+package manager
+
+import (
+	// go
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	// local
+	"gnvm/internal/fake"
+	"gnvm/util"
+)
+
+func newManager(t *testing.T) (*Manager, *fake.Registry) {
+	root, _ := fake.Root(t)
+	reg := fake.NewRegistry(t, nil)
+	m, err := New(Options{Root: root, Registry: reg.URL, NPMRegistry: reg.NPM, Schedule: reg.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, reg
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{}); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("New without root, exit code is %v, want %v", util.ExitCode(err), util.EXIT_USAGE)
+	}
+	m, err := New(Options{Root: t.TempDir(), Registry: "http://127.0.0.1/dist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Registry() != "http://127.0.0.1/dist/" {
+		t.Fatalf("registry is %v, want trailing slash", m.Registry())
+	}
+}
+
+func TestResolve(t *testing.T) {
+	m, _ := newManager(t)
+	fake.Install(t, m.Root(), "", "18.16.0", "x64")
+
+	tests := []struct {
+		spec, want string
+		code       int
+	}{
+		{"latest", "20.1.0", util.EXIT_OK},
+		{"LATEST", "20.1.0", util.EXIT_OK},
+		{"latest-x86", "20.1.0-x86", util.EXIT_OK},
+		{"18.*.*", "18.16.0", util.EXIT_OK},
+		{"16.20.x", "16.20.0", util.EXIT_OK},
+		{"global", "18.16.0", util.EXIT_OK},
+		{"18.16.0-x86", "18.16.0-x86", util.EXIT_OK},
+		{"99.*.*", "", util.EXIT_NOT_INSTALLED},
+		{"abc", "", util.EXIT_USAGE},
+		{"18.16.0-x32", "", util.EXIT_USAGE},
+	}
+	for _, test := range tests {
+		got, err := m.Resolve(test.spec)
+		if got != test.want || util.ExitCode(err) != test.code {
+			t.Errorf("Resolve(%v) = %v, %v, want %v, exit code %v", test.spec, got, err, test.want, test.code)
+		}
+	}
+}
+
+func TestInstallUseUninstall(t *testing.T) {
+	m, reg := newManager(t)
+
+	folder, err := m.Install("latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folder != "20.1.0" || !util.IsDirExist(m.Root(), folder, util.NODE) {
+		t.Fatalf("Install(latest) = %v, node.exe not exist", folder)
+	}
+	if _, err := m.Install("18.16.0-x86"); err != nil {
+		t.Fatal(err)
+	}
+	if arch, _ := util.Arch(filepath.Join(m.Root(), "18.16.0-x86")); arch != "x86" {
+		t.Fatalf("18.16.0-x86 arch is %v", arch)
+	}
+
+	// installed folder not download again
+	n := len(reg.Requests)
+	if _, err := m.Install("20.1.0"); err != nil || len(reg.Requests) != n {
+		t.Fatalf("Install exist folder, err %v, requests %v", err, reg.Requests[n:])
+	}
+
+	if _, err := m.Use("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if global, _ := m.Resolve(util.GLOBAL); global != "20.1.0" {
+		t.Fatalf("global is %v after Use(20.1.0)", global)
+	}
+	if _, err := m.Use("18.16.0-x86"); err != nil {
+		t.Fatal(err)
+	}
+	if global, _ := m.Resolve(util.GLOBAL); global != "18.16.0-x86" {
+		t.Fatalf("global is %v after Use(18.16.0-x86)", global)
+	}
+	if _, err := m.Use("16.20.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Use not installed version, err %v", err)
+	}
+
+	locals, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locals) != 2 || locals[0].Folder != "18.16.0-x86" || !locals[0].Global || locals[0].Arch != "x86" || locals[1].Global {
+		t.Fatalf("List() = %+v", locals)
+	}
+
+	if err := m.Uninstall("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(m.Root(), "20.1.0") {
+		t.Fatal("20.1.0 folder exist after Uninstall")
+	}
+	if err := m.Uninstall("20.1.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Uninstall not exist folder, err %v", err)
+	}
+	if err := m.Uninstall("latest"); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Uninstall(latest), err %v", err)
+	}
+}
+
+func TestInstallPartialMirror(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	root, _ := fake.Root(t)
+	reg := fake.NewRegistry(t, []fake.Release{
+		{Version: "20.1.0", NPM: "9.6.4", Files: []string{"win-x86-exe", "win-x64-zip"}},
+		{Version: "18.16.0", NPM: "9.5.1", Files: []string{"osx-x64-tar"}},
+	})
+	m, err := New(Options{Root: root, Registry: reg.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Install("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if ver, err := util.GetNodeVer(filepath.Join(root, "20.1.0")); err != nil || ver != "20.1.0" {
+		t.Fatalf("Install(20.1.0) from zip, version %v, %v", ver, err)
+	}
+	if util.IsDirExist(root, "20.1.0", "node-v20.1.0-win-x64.zip") {
+		t.Fatal("zip exist after Install")
+	}
+	if _, err := m.Install("20.1.0-x86"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Install("18.16.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED || util.IsDirExist(root, "18.16.0") {
+		t.Fatalf("Install(18.16.0) without Windows build, err %v", err)
+	}
+}
+
+func TestInstallChannel(t *testing.T) {
+	root, _ := fake.Root(t)
+	reg := fake.NewRegistry(t, nil)
+	rc := fake.NewRegistry(t, []fake.Release{
+		{Version: "22.0.0-rc.2", NPM: "10.5.0"},
+		{Version: "22.0.0-rc.1", NPM: "10.5.0"},
+	})
+	progress := map[string][2]int64{}
+	m, err := New(Options{Root: root, Registry: reg.URL, Channels: map[string]string{util.CHANNEL_RC: rc.URL}, Progress: func(name string, done, total int64) {
+		progress[name] = [2]int64{done, total}
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name     string
+		io       bool
+		registry string
+		code     int
+	}{
+		{"", false, reg.URL, util.EXIT_OK},
+		{util.CHANNEL_RELEASE, true, reg.URL, util.EXIT_OK},
+		{util.CHANNEL_RC, false, rc.URL, util.EXIT_OK},
+		{util.CHANNEL_NIGHTLY, false, "", util.EXIT_USAGE},
+	} {
+		if registry, err := m.Channel(test.name, test.io); registry != test.registry || util.ExitCode(err) != test.code {
+			t.Errorf("Channel(%v, %v) = %v, %v", test.name, test.io, registry, err)
+		}
+	}
+	def, _ := New(Options{Root: root})
+	if registry, _ := def.Channel("", true); registry != "https://iojs.org/dist/" {
+		t.Fatalf("Channel(\"\", true) of default registry = %v", registry)
+	}
+
+	if folder, err := m.Install("rc"); err != nil || folder != "rc@22.0.0-rc.2" {
+		t.Fatalf("Install(rc) = %v, %v", folder, err)
+	}
+	if p := progress["rc@22.0.0-rc.2"]; p[0] <= 0 || p[0] != p[1] {
+		t.Fatalf("Install(rc) progress = %v", p)
+	}
+	if folder, err := m.Install("rc/22.0.0-rc.1"); err != nil || folder != "rc@22.0.0-rc.1" {
+		t.Fatalf("Install(rc/22.0.0-rc.1) = %v, %v", folder, err)
+	}
+	if folder, err := m.Install("release/20.1.0"); err != nil || folder != "20.1.0" {
+		t.Fatalf("Install(release/20.1.0) = %v, %v", folder, err)
+	}
+	if _, err := m.Install("nightly"); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Install(nightly) not configured, err %v", err)
+	}
+
+	locals, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locals) != 3 || locals[1].Folder != "rc@22.0.0-rc.1" || locals[1].Version != "rc@22.0.0-rc.1" {
+		t.Fatalf("List() = %+v", locals)
+	}
+
+	// channel global version not backup
+	if _, err := m.Use("rc@22.0.0-rc.1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Use("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(root, "22.0.0-rc.1") {
+		t.Fatal("22.0.0-rc.1 folder exist after Use")
+	}
+}
+
+func TestInstallFrom(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, reg := newManager(t)
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "node-v18.19.0-win-x64.zip")
+	if err := os.WriteFile(archive, fake.Zip(t, "18.19.0", "x64"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	build, err := m.InstallFrom(archive, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if build.Name != "18.19.0" || build.Version != "18.19.0" || build.Arch != "x64" || util.IsDirExist(m.Root(), "18.19.0", "node-v18.19.0-win-x64.zip") {
+		t.Fatalf("InstallFrom(%v) = %+v", archive, build)
+	}
+	if _, err := m.InstallFrom(archive, ""); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("InstallFrom exist folder, err %v", err)
+	}
+
+	reg.Set("/builds/node-custom.zip", fake.Zip(t, "18.19.0", "x86"))
+	if _, err := m.InstallFrom(reg.URL+"builds/node-custom.zip", ""); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("InstallFrom without --as, err %v", err)
+	}
+	if build, err = m.InstallFrom(reg.URL+"builds/node-custom.zip", "18.19.0-Patched"); err != nil {
+		t.Fatal(err)
+	}
+	if read, err := util.ReadBuild(m.Root(), "18.19.0-patched"); err != nil || *read != *build || build.Arch != "x86" {
+		t.Fatalf("ReadBuild(18.19.0-patched) = %+v, %v, want %+v", read, err, build)
+	}
+	if _, err := m.InstallFrom(reg.URL+"builds/missing.zip", "18.19.0-missing"); util.ExitCode(err) != util.EXIT_NETWORK || util.IsDirExist(m.Root(), "18.19.0-missing") {
+		t.Fatalf("InstallFrom 404, err %v", err)
+	}
+	for _, test := range [][2]string{{archive, "latest"}, {filepath.Join(dir, "node.tgz"), "18.19.0-tgz"}, {filepath.Join(dir, "missing.zip"), "18.19.0-missing"}} {
+		if _, err := m.InstallFrom(test[0], test[1]); util.ExitCode(err) != util.EXIT_USAGE {
+			t.Errorf("InstallFrom(%v, %v), err %v", test[0], test[1], err)
+		}
+	}
+
+	// custom build is listed and usable, global custom build not backup
+	locals, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locals) != 2 || locals[1].Folder != "18.19.0-patched" || locals[1].Version != "18.19.0-patched" || locals[1].Arch != "x86" {
+		t.Fatalf("List() = %+v", locals)
+	}
+	if _, err := m.Use("18.19.0-patched"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Install("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Use("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(m.Root(), "18.19.0-x86") {
+		t.Fatal("18.19.0-x86 folder exist after Use")
+	}
+}
+
+func TestImport(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, _ := newManager(t)
+	dir := t.TempDir()
+	write := func(path string, body []byte) {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, body, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("nvm-windows/v18.19.0/node.exe", fake.Node("18.19.0", "x64"))
+	write("nvm-windows/v16.20.0/node.exe", fake.Node("16.20.0", "x86"))
+	write("nvm-windows/settings.txt", []byte("root: nvm-windows"))
+	write("nvm/versions/node/v20.1.0/bin/node", fake.Node("20.1.0", "x64"))
+	write("nvm/versions/node/v18.16.0/bin/node", fake.Node("18.16.0", "x64"))
+	write("nvm/alias/default", []byte("18\n"))
+	write("nodist/v-x64/18.19.0/node.exe", fake.Node("18.19.0", "x64"))
+	write("nodist/v/18.19.0/node.exe", fake.Node("18.19.0", "x86"))
+	write("nodist/.node-version-global", []byte("18.19.0"))
+	write("fnm/node-versions/v20.1.0/installation/node.exe", fake.Node("20.1.0", "x64"))
+	if err := os.Symlink(filepath.Join(dir, "nvm-windows", "v16.20.0"), filepath.Join(dir, "nodejs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "fnm", "aliases"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "fnm", "node-versions", "v20.1.0", "installation"), filepath.Join(dir, "fnm", "aliases", "default")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NVM_SYMLINK", filepath.Join(dir, "nodejs"))
+
+	tests := []struct {
+		tool, want string
+	}{
+		{IMPORT_NVM_WINDOWS, "18.19.0 16.20.0-x86*"},
+		{IMPORT_NVM, "20.1.0 18.16.0*"},
+		{IMPORT_NODIST, "18.19.0* 18.19.0-x86"},
+		{IMPORT_FNM, "20.1.0*"},
+	}
+	scans := map[string][]Foreign{}
+	for _, test := range tests {
+		foreigns, err := Scan(test.tool, filepath.Join(dir, test.tool))
+		if err != nil {
+			t.Fatal(err)
+		}
+		arr := []string{}
+		for _, f := range foreigns {
+			if f.Default {
+				arr = append(arr, f.Folder()+"*")
+			} else {
+				arr = append(arr, f.Folder())
+			}
+		}
+		if got := strings.Join(arr, " "); got != test.want {
+			t.Errorf("Scan(%v) = %v, want %v", test.tool, got, test.want)
+		}
+		scans[test.tool] = foreigns
+	}
+	if _, err := Scan("volta", dir); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Scan(volta), err %v", err)
+	}
+	if _, err := Scan(IMPORT_NVM, filepath.Join(dir, "missing")); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Scan not exist folder, err %v", err)
+	}
+
+	nvmw, nvm := scans[IMPORT_NVM_WINDOWS], scans[IMPORT_NVM]
+	if folder, err := m.Import(nvmw[0], IMPORT_COPY); err != nil || folder != "18.19.0" || !util.IsDirExist(nvmw[0].Exec) {
+		t.Fatalf("Import(copy) = %v, %v", folder, err)
+	}
+	if folder, err := m.Import(nvmw[1], IMPORT_MOVE); err != nil || folder != "16.20.0-x86" || util.IsDirExist(nvmw[1].Exec) {
+		t.Fatalf("Import(move) = %v, %v", folder, err)
+	}
+	if folder, err := m.Import(nvm[0], IMPORT_LINK); err != nil || folder != "20.1.0" {
+		t.Fatalf("Import(link) = %v, %v", folder, err)
+	}
+	if _, err := m.Import(nvm[1], "zip"); util.ExitCode(err) != util.EXIT_USAGE || util.IsDirExist(m.Root(), "18.16.0") {
+		t.Fatalf("Import(zip), err %v", err)
+	}
+	locals, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locals) != 3 || locals[0].Folder != "16.20.0-x86" || locals[0].Arch != "x86" {
+		t.Fatalf("List() = %+v", locals)
+	}
+}
+
+func TestUseBackupGlobal(t *testing.T) {
+	m, _ := newManager(t)
+	fake.Install(t, m.Root(), "", "16.20.0", "x64")
+	fake.Install(t, m.Root(), "20.1.0", "20.1.0", "x64")
+
+	if _, err := m.Use("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(m.Root(), "16.20.0", util.NODE) {
+		t.Fatal("old global node.exe not backup to 16.20.0 folder")
+	}
+}
+
+func TestInstallNetworkError(t *testing.T) {
+	m, reg := newManager(t)
+	reg.Set("/v18.16.0/win-x64/"+util.NODE, nil)
+
+	if _, err := m.Install("18.16.0"); util.ExitCode(err) != util.EXIT_NETWORK {
+		t.Fatalf("Install 404, exit code %v, err %v", util.ExitCode(err), err)
+	}
+	if util.IsDirExist(m.Root(), "18.16.0") {
+		t.Fatal("18.16.0 folder exist after download fail")
+	}
+
+	reg.Server.Close()
+	if _, err := m.ListRemote(); util.ExitCode(err) != util.EXIT_NETWORK {
+		t.Fatalf("ListRemote closed registry, err %v", err)
+	}
+}
+
+func TestListRemote(t *testing.T) {
+	m, _ := newManager(t)
+	remotes, err := m.ListRemote()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remotes) != len(fake.Releases) {
+		t.Fatalf("ListRemote() count %v, want %v", len(remotes), len(fake.Releases))
+	}
+	if r := remotes[1]; r.Version != "v18.16.0" || r.NPM != "9.5.1" || r.LTS != "Hydrogen" || len(r.Files) != 2 {
+		t.Fatalf("ListRemote()[1] = %+v", r)
+	}
+}
+
+func TestInstallNPM(t *testing.T) {
+	m, _ := newManager(t)
+
+	if _, err := m.InstallNPM(util.GLOBAL); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("InstallNPM(global) without global node.exe, err %v", err)
+	}
+
+	fake.Install(t, m.Root(), "", "18.16.0", "x64")
+	ver, err := m.InstallNPM(util.GLOBAL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ver != "9.5.1" || !util.IsDirExist(m.Root(), "node_modules", "npm", "package.json") || !util.IsDirExist(m.Root(), "npm.cmd") {
+		t.Fatalf("InstallNPM(global) = %v, npm not installed", ver)
+	}
+	if out, _ := util.Runner.Output(filepath.Join(m.Root(), util.NPM), "-v"); string(out) != "9.5.1\n" {
+		t.Fatalf("npm -v is %q", out)
+	}
+
+	if ver, err := m.InstallNPM(util.LATEST); err != nil || ver != "9.6.4" {
+		t.Fatalf("InstallNPM(latest) = %v, %v", ver, err)
+	}
+	if util.IsDirExist(m.Root(), "npm-9.6.4.tgz") {
+		t.Fatal("npm tgz not removed")
+	}
+}
+
+/*
+FS return error when create file
+*/
+type readonlyFS struct {
+	util.OSFS
+}
+
+func (readonlyFS) Create(name string) (io.WriteCloser, error) {
+	return nil, &os.PathError{Op: "create", Path: name, Err: errors.New("read-only file system")}
+}
+
+func TestInstallFSError(t *testing.T) {
+	m, _ := newManager(t)
+	fs := util.FileSystem
+	util.FileSystem = readonlyFS{}
+	defer func() { util.FileSystem = fs }()
+
+	if _, err := m.Install("20.1.0"); util.ExitCode(err) != util.EXIT_ERROR {
+		t.Fatalf("Install read-only, exit code %v, err %v", util.ExitCode(err), err)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name, body string
+		code       int
+	}{
+		{"gnvm.json", `{"versions": ["18.16.0", "Latest"], "global": "18.16.0", "aliases": {"latest": "latest"}}`, util.EXIT_OK},
+		{"gnvm.toml", "# team\nversions = [\n  \"18.16.0\", # lts\n  'latest',\n]\nglobal = \"18.16.0\"\nprune = true\n\n[aliases]\nlatest = \"latest\"\n", util.EXIT_OK},
+		{"empty.json", `{"versions": []}`, util.EXIT_USAGE},
+		{"global.json", `{"versions": ["18.16.0"], "global": "16.20.0"}`, util.EXIT_USAGE},
+		{"alias.json", `{"versions": ["18.16.0"], "aliases": {"lts": "18.16.0"}}`, util.EXIT_USAGE},
+		{"key.toml", "versions = [\"18.16.0\"]\nnode = \"18.16.0\"\n", util.EXIT_USAGE},
+		{"quote.toml", "versions = [18.16.0]\n", util.EXIT_USAGE},
+		{"none.json", "", util.EXIT_USAGE},
+	}
+	for _, test := range tests {
+		path := filepath.Join(dir, test.name)
+		if test.name != "none.json" {
+			if err := os.WriteFile(path, []byte(test.body), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		mf, err := ReadManifest(path)
+		if util.ExitCode(err) != test.code {
+			t.Errorf("ReadManifest(%v) exit code is %v, want %v, err %v", test.name, util.ExitCode(err), test.code, err)
+			continue
+		}
+		if err == nil && (strings.Join(mf.Versions, ",") != "18.16.0,latest" || mf.Global != "18.16.0" || mf.Aliases[util.LATEST] != "latest") {
+			t.Errorf("ReadManifest(%v) = %+v", test.name, mf)
+		}
+	}
+	if FindManifest(dir) != filepath.Join(dir, MANIFEST_JSON) {
+		t.Fatalf("FindManifest(%v) = %v", dir, FindManifest(dir))
+	}
+}
+
+func TestPlan(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, _ := newManager(t)
+	fake.Install(t, m.Root(), "18.16.0", "18.16.0", "x64")
+	fake.Install(t, m.Root(), "16.20.0", "16.20.0", "x64")
+
+	mf := &Manifest{Versions: []string{"18.16.0", "latest", "20.1.0"}, Global: "latest"}
+	plan, err := m.Plan(mf, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(plan.Install, ",") != "20.1.0" || strings.Join(plan.Keep, ",") != "18.16.0" || len(plan.Remove) != 0 {
+		t.Fatalf("Plan() = %+v", plan)
+	}
+	if plan.Folders["latest"] != "20.1.0" {
+		t.Fatalf("Plan() latest folder is %v", plan.Folders["latest"])
+	}
+	if plan, err = m.Plan(mf, true); err != nil || strings.Join(plan.Remove, ",") != "16.20.0" {
+		t.Fatalf("Plan(prune) = %+v, %v", plan, err)
+	}
+	if _, err := m.Plan(&Manifest{Versions: []string{"99.*.*"}}, false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Plan(99.*.*) err %v", err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, _ := newManager(t)
+	if _, err := m.Install("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	fake.Install(t, m.Root(), "16.20.0", "16.20.0", "x64")
+	if _, err := m.Use("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := m.Lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Versions) != 2 || lock.Global != "18.16.0" {
+		t.Fatalf("Lock() = %+v", lock)
+	}
+	if v := lock.Versions[1]; v.Folder != "18.16.0" || v.Registry != m.Registry() || len(v.SHA256) != 1 || v.SHA256[util.NODE] == "" {
+		t.Fatalf("Lock() 18.16.0 = %+v", v)
+	}
+	if v := lock.Versions[0]; v.Folder != "16.20.0" || v.Registry != "" {
+		t.Fatalf("Lock() 16.20.0 = %+v", v)
+	}
+
+	path := filepath.Join(t.TempDir(), LOCKFILE)
+	if err := WriteLock(path, lock); err != nil {
+		t.Fatal(err)
+	}
+	want, err := ReadLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drifts := CompareLock(want, lock); len(drifts) != 0 {
+		t.Fatalf("CompareLock() = %+v, want same", drifts)
+	}
+
+	fake.Install(t, m.Root(), "16.20.0", "16.20.1", "x64")
+	if err := m.Uninstall("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	fake.Install(t, m.Root(), "20.1.0", "20.1.0", "x64")
+	got, err := m.Lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := []string{}
+	for _, d := range CompareLock(want, got) {
+		fields = append(fields, d.Folder+" "+d.Field)
+	}
+	if strings.Join(fields, ",") != "16.20.0 sha256:node.exe,18.16.0 missing,20.1.0 extra, global" {
+		t.Fatalf("CompareLock() = %v", fields)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, reg := newManager(t)
+	if _, err := m.Install("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	check, err := m.Verify("18.16.0")
+	if err != nil || check.Status != CHECK_OK || check.From != m.Registry()+"v18.16.0/"+util.SHASUMS {
+		t.Fatalf("Verify(18.16.0) = %+v, %v", check, err)
+	}
+
+	// corrupted node.exe, repair from registry
+	node := filepath.Join(m.Root(), "18.16.0", util.NODE)
+	if err := os.WriteFile(node, fake.Node("18.16.1", "x64"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.Verify("18.16.0"); check.Status != CHECK_CHECKSUM {
+		t.Fatalf("Verify(18.16.0) corrupted = %+v", check)
+	}
+	if err := m.Repair("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.Verify("18.16.0"); check.Status != CHECK_OK || util.IsDirExist(m.Root(), "18.16.0.repair") {
+		t.Fatalf("Verify(18.16.0) repaired = %+v", check)
+	}
+
+	// without gnvm-source.json and SHASUMS256.txt, only check node --version
+	reg.Set("/v16.20.0/"+util.SHASUMS, nil)
+	fake.Install(t, m.Root(), "16.20.0", "16.20.0", "x64")
+	if check, _ := m.Verify("16.20.0"); check.Status != CHECK_UNVERIFIED {
+		t.Fatalf("Verify(16.20.0) = %+v", check)
+	}
+	fake.Install(t, m.Root(), "16.20.0", "16.20.1", "x64")
+	if check, _ := m.Verify("16.20.0"); check.Status != CHECK_VERSION || check.Version != "16.20.1" {
+		t.Fatalf("Verify(16.20.0) version = %+v", check)
+	}
+	if err := os.Remove(filepath.Join(m.Root(), "16.20.0", util.NODE)); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.Verify("16.20.0"); check.Status != CHECK_MISSING {
+		t.Fatalf("Verify(16.20.0) missing = %+v", check)
+	}
+	if _, err := m.Verify("20.1.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Verify(20.1.0) err %v", err)
+	}
+
+	// global node.exe
+	if _, err := m.Use("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.VerifyGlobal("18.16.0"); check.Status != CHECK_OK {
+		t.Fatalf("VerifyGlobal(18.16.0) = %+v", check)
+	}
+	if err := os.WriteFile(filepath.Join(m.Root(), util.NODE), []byte("v18.16"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.VerifyGlobal("18.16.0"); check.Status != CHECK_CHECKSUM {
+		t.Fatalf("VerifyGlobal(18.16.0) half-copied = %+v", check)
+	}
+	if err := m.RepairGlobal("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	if check, _ := m.VerifyGlobal("18.16.0"); check.Status != CHECK_OK {
+		t.Fatalf("VerifyGlobal(18.16.0) repaired = %+v", check)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, _ := newManager(t)
+	old := time.Now().AddDate(0, 0, -200)
+	for _, v := range []string{"20.1.0", "18.16.0", "18.15.0", "16.20.0", "16.19.0"} {
+		fake.Install(t, m.Root(), v, v, "x64")
+		if err := os.Chtimes(filepath.Join(m.Root(), v, util.NODE), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []string{"18.15.0", "20.1.0"} {
+		if _, err := m.Use(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		policy PrunePolicy
+		want   string
+	}{
+		{PrunePolicy{Keep: 2}, "18.15.0,16.20.0,16.19.0"},
+		{PrunePolicy{KeepLatestPerMajor: 1}, "18.15.0,16.19.0"},
+		{PrunePolicy{KeepLatestPerMajor: 1, Protect: []string{"16.19.0"}}, "18.15.0"},
+		{PrunePolicy{OlderThan: time.Now().AddDate(0, 0, -180)}, "18.16.0,18.15.0,16.20.0,16.19.0"},
+		{PrunePolicy{OlderThan: time.Now().AddDate(0, 0, -300)}, ""},
+		{PrunePolicy{UnusedSince: time.Now().Add(-time.Hour)}, "18.16.0,16.20.0,16.19.0"},
+	}
+	for _, test := range tests {
+		pruned, err := m.Prune(test.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		folders := []string{}
+		for _, v := range pruned {
+			if v.Size == 0 {
+				t.Errorf("Prune(%+v) %v size is 0", test.policy, v.Folder)
+			}
+			folders = append(folders, v.Folder)
+		}
+		if got := strings.Join(folders, ","); got != test.want {
+			t.Errorf("Prune(%+v) = %v, want %v", test.policy, got, test.want)
+		}
+	}
+}
+
+func TestMeta(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	m, reg := newManager(t)
+	if _, err := m.Install("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	fake.Install(t, m.Root(), "18.16.0", "18.16.0", "x64")
+
+	meta := m.Meta("20.1.0")
+	if meta.Size <= 0 || meta.Installed.IsZero() || !meta.Used.IsZero() || meta.Registry != reg.URL {
+		t.Fatalf("Meta(20.1.0) = %+v", meta)
+	}
+	if meta = m.Meta("18.16.0"); meta.Size <= 0 || meta.Installed.IsZero() || meta.Registry != "" {
+		t.Fatalf("Meta(18.16.0) = %+v", meta)
+	}
+	if _, err := m.Use("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	if meta = m.Meta("18.16.0"); meta.Used.IsZero() {
+		t.Fatalf("Meta(18.16.0) after use = %+v", meta)
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	root, _ := fake.Root(t)
+	reg := fake.NewRegistry(t, []fake.Release{
+		{Version: "20.1.0", NPM: "9.6.4"},
+		{Version: "18.17.0", NPM: "9.6.7", LTS: "Hydrogen"},
+		{Version: "18.16.1", NPM: "9.5.1", LTS: "Hydrogen", Security: true},
+		{Version: "18.16.0", NPM: "9.5.1", LTS: "Hydrogen"},
+		{Version: "16.20.1", NPM: "8.19.4", LTS: "Gallium"},
+		{Version: "16.20.0", NPM: "8.19.4", LTS: "Gallium"},
+	})
+	reg.Set("/"+util.SCHEDULE, []byte(`{"v20": {"end": "2099-04-30"}, "v18": {"end": "2099-04-30"}, "v16": {"end": "2023-09-11"}}`))
+	m, err := New(Options{Root: root, Registry: reg.URL, Schedule: reg.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"20.1.0", "18.16.0-x86", "16.20.0"} {
+		fake.Install(t, root, v, util.TrimArch(v), "x64")
+	}
+
+	outdated, err := m.Outdated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Outdated{
+		"20.1.0":      {"20.1.0", "20.1.0", "20.1.0", "", "v20", false, false, "2099-04-30"},
+		"18.16.0-x86": {"18.16.0-x86", "18.16.0", "18.17.0", "18.17.0-x86", "v18", true, false, "2099-04-30"},
+		"16.20.0":     {"16.20.0", "16.20.0", "16.20.1", "16.20.1", "v16", false, true, "2023-09-11"},
+	}
+	if len(outdated) != len(want) {
+		t.Fatalf("Outdated() = %+v", outdated)
+	}
+	for _, o := range outdated {
+		if o != want[o.Folder] {
+			t.Errorf("Outdated() %v = %+v, want %+v", o.Folder, o, want[o.Folder])
+		}
+	}
+
+	reg.Set("/"+util.SCHEDULE, nil)
+	if outdated, err = m.Outdated(); err != nil || outdated[0].End != "" {
+		t.Fatalf("Outdated() without schedule = %+v, %v", outdated, err)
+	}
+}
+
+func TestAdvisory(t *testing.T) {
+	m, reg := newManager(t)
+	reg.Set("/"+util.SCHEDULE, []byte(`{"v18": {"end": "2099-04-30"}, "v16": {"end": "2023-09-11"}}`))
+
+	if cache := m.CachedAdvisory(); cache != nil {
+		t.Fatalf("CachedAdvisory() without cache = %+v", cache)
+	}
+	advisory, err := m.Advisory(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(m.Root(), ADVISORY) || strings.Join(advisory.Security, ",") != "16.20.0" {
+		t.Fatalf("Advisory() = %+v", advisory)
+	}
+	now := time.Now()
+	if w := advisory.Warn("16.19.0-x86", now); w == nil || !w.EOL || w.End != "2023-09-11" || w.Security != "16.20.0" || w.Line != "v16" {
+		t.Fatalf("Warn(16.19.0-x86) = %+v", w)
+	}
+	if w := advisory.Warn("16.20.0", now); w == nil || !w.EOL || w.Security != "" {
+		t.Fatalf("Warn(16.20.0) = %+v", w)
+	}
+	for _, v := range []string{"18.16.0", "rc@22.0.0-rc.1", "18.19.0-patched"} {
+		if w := advisory.Warn(v, now); w != nil {
+			t.Fatalf("Warn(%v) = %+v", v, w)
+		}
+	}
+
+	// cache and stale cache
+	reg.Set("/"+util.SCHEDULE, nil)
+	if advisory, err = m.Advisory(false); err != nil || advisory.Schedule["v16"].End != "2023-09-11" {
+		t.Fatalf("Advisory() cache = %+v, %v", advisory, err)
+	}
+	if advisory, err = m.Advisory(true); err != nil || advisory.Schedule["v16"].End != "2023-09-11" {
+		t.Fatalf("Advisory(refresh) stale cache = %+v, %v", advisory, err)
+	}
+	if cache := m.CachedAdvisory(); cache == nil || cache.Schedule["v16"].End != "2023-09-11" {
+		t.Fatalf("CachedAdvisory() = %+v", cache)
+	}
+	if other, _ := New(Options{Root: m.Root(), Registry: "http://127.0.0.1/dist/", Schedule: reg.URL}); other.CachedAdvisory() != nil {
+		t.Fatal("CachedAdvisory() of other registry is not nil")
+	}
+	if err := os.Remove(filepath.Join(m.Root(), ADVISORY)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Advisory(true); util.ExitCode(err) != util.EXIT_NETWORK {
+		t.Fatalf("Advisory(refresh) without cache, err %v", err)
+	}
+}
+
+func TestGlobals(t *testing.T) {
+	m, _ := newManager(t)
+	write := func(path, body string) {
+		path = filepath.Join(m.Root(), filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake.Install(t, m.Root(), "", "18.16.0", "x64")
+	fake.Install(t, m.Root(), "18.16.0", "18.16.0", "x64")
+	fake.Install(t, m.Root(), "20.1.0", "20.1.0", "x64")
+	write("node_modules/typescript/package.json", `{"name": "typescript", "version": "5.4.5"}`)
+	write("node_modules/@vue/cli/package.json", `{"name": "@vue/cli", "version": "5.0.8"}`)
+	write("node_modules/npm/package.json", `{"name": "npm", "version": "9.5.1"}`)
+	write("node_modules/npm/bin/npm-cli.js", "npm")
+	write("node_modules/broken/package.json", `{`)
+	write("node_modules/.bin/tsc", "tsc")
+
+	if _, err := m.Globals("14.0.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Globals(14.0.0), err %v", err)
+	}
+	if pkgs, err := m.Globals("20.1.0"); err != nil || len(pkgs) != 0 {
+		t.Fatalf("Globals(20.1.0) = %v, %v", pkgs, err)
+	}
+	pkgs, err := m.Globals("18.16.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 2 || pkgs[0] != (Package{"@vue/cli", "5.0.8"}) || pkgs[1] != (Package{"typescript", "5.4.5"}) {
+		t.Fatalf("Globals(18.16.0) = %v", pkgs)
+	}
+
+	exec := util.Runner.(*fake.Exec)
+	node, cli := filepath.Join(m.Root(), "20.1.0", util.NODE), filepath.Join(m.Root(), "node_modules", "npm", "bin", "npm-cli.js")
+	for latest, specs := range map[bool]string{false: "@vue/cli@5.0.8 typescript@5.4.5", true: "@vue/cli@latest typescript@latest"} {
+		if err := m.InstallGlobals("20.1.0", pkgs, latest); err != nil {
+			t.Fatal(err)
+		}
+		if want := node + " " + cli + " install -g --prefix " + filepath.Join(m.Root(), "20.1.0") + " " + specs; exec.Calls[len(exec.Calls)-1] != want {
+			t.Fatalf("InstallGlobals(20.1.0, %v) run %q, want %q", latest, exec.Calls[len(exec.Calls)-1], want)
+		}
+	}
+	if err := m.InstallGlobals("18.16.0", pkgs, false); err != nil {
+		t.Fatal(err)
+	}
+	if call := exec.Calls[len(exec.Calls)-1]; !strings.Contains(call, " --prefix "+m.Root()+" ") {
+		t.Fatalf("InstallGlobals(global) run %q, prefix is not root", call)
+	}
+
+	if err := os.RemoveAll(filepath.Join(m.Root(), "node_modules", "npm")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.InstallGlobals("20.1.0", pkgs, false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("InstallGlobals without npm, err %v", err)
+	}
+}
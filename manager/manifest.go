@@ -0,0 +1,297 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Manifest file name, usage gnvm sync, json is first
+*/
+const (
+	MANIFEST_JSON = "gnvm.json"
+	MANIFEST_TOML = "gnvm.toml"
+)
+
+/*
+Manifest aliases, gnvm built-in alias is global and latest, e.g. latestversion of .gnvmrc
+*/
+var MANIFEST_ALIASES = []string{util.GLOBAL, util.LATEST}
+
+/*
+Node.js version set of this machine, e.g. gnvm.toml:
+
+		versions = ["14.21.3-x86", "18.19.0", "20.11.0"]
+		global   = "18.19.0"
+		npm      = "10.2.4"
+		prune    = false
+
+		[aliases]
+		latest = "20.11.0"
+
+	  - Versions: Node.js versions, see Resolve
+	  - Global:   global Node.js version, must be in Versions
+	  - NPM:      npm version, when "", not change npm
+	  - Aliases:  key include: global latest, value must be in Versions
+	  - Prune:    when true, remove local Node.js versions which not in Versions
+*/
+type Manifest struct {
+	Versions []string          `json:"versions"`
+	Global   string            `json:"global"`
+	NPM      string            `json:"npm"`
+	Aliases  map[string]string `json:"aliases"`
+	Prune    bool              `json:"prune"`
+}
+
+/*
+Return manifest path of folder, gnvm.json first, then gnvm.toml, when not found, return ""
+*/
+func FindManifest(dir string) string {
+	for _, name := range []string{MANIFEST_JSON, MANIFEST_TOML} {
+		if util.IsDirExist(dir, name) {
+			return filepath.Join(dir, name)
+		}
+	}
+	return ""
+}
+
+/*
+Read and validate manifest, format by extension, .toml is toml, others is json
+
+Param:
+  - path: e.g. gnvm.json gnvm.toml
+
+Return:
+  - *Manifest: Aliases include global when Global != ""
+  - error:     EXIT_USAGE when manifest invalid
+*/
+func ReadManifest(path string) (*Manifest, error) {
+	file, err := util.FileSystem.Open(path)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v", err)
+	}
+	defer file.Close()
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "read %v Error: %v", path, err)
+	}
+
+	mf := &Manifest{}
+	if strings.ToLower(filepath.Ext(path)) == ".toml" {
+		err = parseTOML(string(body), mf)
+	} else {
+		err = json.Unmarshal(body, mf)
+	}
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "parse %v Error: %v", path, err)
+	}
+	if err := mf.validate(); err != nil {
+		return nil, util.Errorf(util.EXIT_USAGE, "%v %v", path, err)
+	}
+	return mf, nil
+}
+
+func (mf *Manifest) validate() error {
+	if len(mf.Versions) == 0 {
+		return fmt.Errorf("versions is empty")
+	}
+	exist := map[string]bool{}
+	for i, v := range mf.Versions {
+		mf.Versions[i] = strings.ToLower(strings.TrimSpace(v))
+		if !util.VerifyNodeVer(mf.Versions[i]) || mf.Versions[i] == util.GLOBAL || mf.Versions[i] == util.UNKNOWN {
+			return fmt.Errorf("versions %v not an valid Node.js version", v)
+		}
+		exist[mf.Versions[i]] = true
+	}
+	if mf.Aliases == nil {
+		mf.Aliases = map[string]string{}
+	}
+	if mf.Global = strings.ToLower(strings.TrimSpace(mf.Global)); mf.Global != "" {
+		if alias, ok := mf.Aliases[util.GLOBAL]; ok && strings.ToLower(alias) != mf.Global {
+			return fmt.Errorf("global %v and aliases.global %v is different", mf.Global, alias)
+		}
+		mf.Aliases[util.GLOBAL] = mf.Global
+	}
+	for name, v := range mf.Aliases {
+		if name != util.GLOBAL && name != util.LATEST {
+			return fmt.Errorf("aliases %v not support, include: %v", name, strings.Join(MANIFEST_ALIASES, ", "))
+		}
+		if mf.Aliases[name] = strings.ToLower(strings.TrimSpace(v)); !exist[mf.Aliases[name]] {
+			return fmt.Errorf("aliases %v %v must be in versions", name, v)
+		}
+	}
+	mf.Global = mf.Aliases[util.GLOBAL]
+	if mf.NPM = strings.ToLower(strings.TrimSpace(mf.NPM)); mf.NPM != "" && !util.VerifyNodeVer(mf.NPM) {
+		return fmt.Errorf("npm %v not an valid npm version", mf.NPM)
+	}
+	return nil
+}
+
+/*
+Parse toml subset, include: string, bool, string array and [aliases] table
+*/
+func parseTOML(body string, mf *Manifest) error {
+	table, lines := "", strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := stripComment(lines[i])
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			table = strings.TrimSpace(line[1 : len(line)-1])
+			if table != "aliases" {
+				return fmt.Errorf("line %v table [%v] not support", i+1, table)
+			}
+			continue
+		}
+		arr := strings.SplitN(line, "=", 2)
+		if len(arr) != 2 {
+			return fmt.Errorf("line %v %v must be key = value", i+1, line)
+		}
+		key, value := strings.TrimSpace(arr[0]), strings.TrimSpace(arr[1])
+
+		// multi-line array
+		for strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]") && i+1 < len(lines) {
+			i++
+			value += stripComment(lines[i])
+		}
+
+		var err error
+		switch {
+		case table == "aliases":
+			if mf.Aliases == nil {
+				mf.Aliases = map[string]string{}
+			}
+			mf.Aliases[key], err = tomlString(value)
+		case key == "versions":
+			mf.Versions, err = tomlArray(value)
+		case key == "global":
+			mf.Global, err = tomlString(value)
+		case key == "npm":
+			mf.NPM, err = tomlString(value)
+		case key == "prune":
+			mf.Prune, err = strconv.ParseBool(value)
+		default:
+			err = fmt.Errorf("key not support")
+		}
+		if err != nil {
+			return fmt.Errorf("line %v %v, %v", i+1, key, err)
+		}
+	}
+	return nil
+}
+
+func stripComment(line string) string {
+	quote := rune(0)
+	for i, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+func tomlString(value string) (string, error) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1], nil
+	}
+	return "", fmt.Errorf("%v must be quoted string", value)
+}
+
+func tomlArray(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("%v must be array", value)
+	}
+	arr := []string{}
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		s, err := tomlString(item)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, s)
+	}
+	return arr, nil
+}
+
+/*
+Sync plan of manifest
+
+  - Install: folders which not installed, e.g. 14.21.3-x86
+  - Remove:  local folders which not in manifest, only when prune
+  - Keep:    folders which already installed
+  - Folders: manifest version to folder, e.g. latest: 20.11.0
+*/
+type Plan struct {
+	Install []string          `json:"install"`
+	Remove  []string          `json:"remove"`
+	Keep    []string          `json:"keep"`
+	Folders map[string]string `json:"folders"`
+}
+
+/*
+Create sync plan, versions are resolved by Resolve, e.g. latest to x.xx.xx
+
+Param:
+  - mf:    *Manifest
+  - prune: when true, plan remove local folders which not in manifest
+
+Return:
+  - *Plan
+  - error
+*/
+func (m *Manager) Plan(mf *Manifest, prune bool) (*Plan, error) {
+	locals, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	installed := map[string]bool{}
+	for _, local := range locals {
+		installed[local.Folder] = true
+	}
+
+	plan, listed := &Plan{[]string{}, []string{}, []string{}, map[string]string{}}, map[string]bool{}
+	for _, v := range mf.Versions {
+		folder := v
+		if !installed[v] {
+			if folder, err = m.Resolve(v); err != nil {
+				return nil, err
+			}
+		}
+		plan.Folders[v] = folder
+		if listed[folder] {
+			continue
+		}
+		listed[folder] = true
+		if installed[folder] {
+			plan.Keep = append(plan.Keep, folder)
+		} else {
+			plan.Install = append(plan.Install, folder)
+		}
+	}
+	if prune {
+		for _, local := range locals {
+			if !listed[local.Folder] {
+				plan.Remove = append(plan.Remove, local.Folder)
+			}
+		}
+	}
+	sort.Strings(plan.Keep)
+	return plan, nil
+}
@@ -0,0 +1,164 @@
+package manager
+
+import (
+	// go
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+const (
+	NPM_REGISTRY = "https://registry.npmjs.org/npm/"
+	NPM_PACKAGE  = "package/"
+)
+
+/*
+Download and install npm to <root>/node_modules/npm, copy npm and npm.cmd to <root>
+
+Param:
+  - spec: include: latest global x.xx.xx
+
+Return:
+  - version: installed npm version
+  - error
+*/
+func (m *Manager) InstallNPM(spec string) (string, error) {
+	ver, err := m.resolveNPM(strings.ToLower(strings.TrimSpace(spec)))
+	if err != nil {
+		return "", err
+	}
+
+	url := m.npmRegistry + "-/npm-" + ver + ".tgz"
+	tgz := filepath.Join(m.root, "npm-"+ver+".tgz")
+	m.logger.Printf("download npm %v from %v", ver, url)
+	if err := m.download(url, tgz, "npm@"+ver); err != nil {
+		return "", err
+	}
+	defer util.FileSystem.Remove(tgz)
+
+	modules := filepath.Join(m.root, "node_modules")
+	npmPath := filepath.Join(modules, util.NPM)
+	if err := util.FileSystem.RemoveAll(npmPath); err != nil {
+		return "", util.Errorf(util.EXIT_ERROR, "remove %v Error: %v", npmPath, err)
+	}
+	if err := untgz(tgz, npmPath); err != nil {
+		return "", err
+	}
+	for _, name := range []string{"npm", "npm.cmd"} {
+		if !util.IsDirExist(npmPath, "bin", name) {
+			continue
+		}
+		if err := util.Copy(filepath.Join(npmPath, "bin"), m.root, name); err != nil {
+			return "", util.Errorf(util.EXIT_ERROR, "copy %v to %v Error: %v", name, m.root, err)
+		}
+	}
+	m.logger.Printf("set success, current npm version is %v", ver)
+	return ver, nil
+}
+
+/*
+Resolve npm version
+
+Param:
+  - spec: include: latest global x.xx.xx
+
+Return:
+  - version: npm version, e.g. 3.8.1
+  - error
+*/
+func (m *Manager) resolveNPM(spec string) (string, error) {
+	switch spec {
+	case util.LATEST:
+		res, err := m.get(m.npmRegistry + util.LATEST)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+		pkg := struct {
+			Version string `json:"version"`
+		}{}
+		if err := json.NewDecoder(res.Body).Decode(&pkg); err != nil || pkg.Version == "" {
+			return "", util.Errorf(util.EXIT_NETWORK, "parse %v Error: %v", m.npmRegistry+util.LATEST, err)
+		}
+		return pkg.Version, nil
+	case util.GLOBAL:
+		global, err := m.Resolve(util.GLOBAL)
+		if err != nil {
+			return "", err
+		}
+		ver := "v" + strings.Split(global, "-")[0]
+		remotes, err := m.ListRemote()
+		if err != nil {
+			return "", err
+		}
+		for _, r := range remotes {
+			if r.Version == ver && r.NPM != "" {
+				return r.NPM, nil
+			}
+		}
+		return "", util.Errorf(util.EXIT_NOT_INSTALLED, "Node.js %v not bundled npm", ver)
+	}
+	if !util.VerifyNodeVer(spec) {
+		return "", &util.VersionError{Input: spec, Err: util.ErrInvalidVersion}
+	}
+	return spec, nil
+}
+
+/*
+Extract npm tgz package/ folder to dst
+*/
+func untgz(src, dst string) error {
+	file, err := util.FileSystem.Open(src)
+	if err != nil {
+		return util.Errorf(util.EXIT_ERROR, "open %v Error: %v", src, err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return util.Errorf(util.EXIT_CHECKSUM, "read %v Error: %v", src, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return util.Errorf(util.EXIT_CHECKSUM, "read %v Error: %v", src, err)
+		}
+		name := strings.TrimPrefix(filepath.ToSlash(hdr.Name), NPM_PACKAGE)
+		path := filepath.Join(dst, filepath.FromSlash(name))
+		if !strings.HasPrefix(path, filepath.Clean(dst)+string(os.PathSeparator)) {
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := util.FileSystem.MkdirAll(path, 0755); err != nil {
+				return util.Errorf(util.EXIT_ERROR, "create %v Error: %v", path, err)
+			}
+		case tar.TypeReg:
+			if err := util.FileSystem.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return util.Errorf(util.EXIT_ERROR, "create %v Error: %v", path, err)
+			}
+			out, err := util.FileSystem.Create(path)
+			if err != nil {
+				return util.Errorf(util.EXIT_ERROR, "create %v Error: %v", path, err)
+			}
+			_, err = io.Copy(out, tr)
+			out.Close()
+			if err != nil {
+				return util.Errorf(util.EXIT_ERROR, "write %v Error: %v", path, err)
+			}
+		}
+	}
+}
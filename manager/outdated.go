@@ -0,0 +1,134 @@
+package manager
+
+import (
+	// go
+	"encoding/json"
+	"strings"
+	"time"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Node.js release line of <schedule>/schedule.json, key is line, e.g. v18 v0.12
+
+  - Start:       release date, e.g. 2022-04-19
+  - LTS:         active lts date, when "" not lts
+  - Maintenance: maintenance date
+  - End:         end-of-life date
+  - Codename:    lts codename, e.g. Hydrogen
+*/
+type Line struct {
+	Start       string `json:"start"`
+	LTS         string `json:"lts"`
+	Maintenance string `json:"maintenance"`
+	End         string `json:"end"`
+	Codename    string `json:"codename"`
+}
+
+/*
+Return true when line is past end-of-life at now, when End is invalid, return false
+*/
+func (l Line) EOL(now time.Time) bool {
+	end, err := time.Parse("2006-01-02", l.End)
+	return err == nil && now.After(end)
+}
+
+/*
+Local Node.js version compare to the newest remote patch in the same line
+
+  - Folder:   folder name, e.g. x.xx.xx-x86
+  - Version:  x.xx.xx
+  - Latest:   the newest remote version in the same line, when up to date, it is Version
+  - Target:   folder name of Latest, e.g. x.xx.xx-x86, when up to date, it is ""
+  - Line:     release line, e.g. v18 v0.12, see util.NodeLine
+  - Security: true when a security release is newer than Version in the same line
+  - EOL:      true when line is past end-of-life
+  - End:      end-of-life date of line, when unknown, it is ""
+*/
+type Outdated struct {
+	Folder   string `json:"folder"`
+	Version  string `json:"version"`
+	Latest   string `json:"latest"`
+	Target   string `json:"target"`
+	Line     string `json:"line"`
+	Security bool   `json:"security"`
+	EOL      bool   `json:"eol"`
+	End      string `json:"end"`
+}
+
+/*
+Get Node.js release schedule from <schedule>/schedule.json
+
+Return:
+  - map[string]Line: key is line, e.g. v18 v0.12
+  - error
+*/
+func (m *Manager) Schedule() (map[string]Line, error) {
+	res, err := m.get(m.schedule + util.SCHEDULE)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	lines := map[string]Line{}
+	if err := json.NewDecoder(res.Body).Decode(&lines); err != nil {
+		return nil, util.Errorf(util.EXIT_NETWORK, "parse %v Error: %v", m.schedule+util.SCHEDULE, err)
+	}
+	return lines, nil
+}
+
+/*
+Compare local Node.js versions to the newest remote patch in the same line, channel, io.js and custom build are skipped
+
+Return:
+  - []Outdated: order is the same as List
+  - error:      get <registry>/index.json error, schedule.json error only logged
+*/
+func (m *Manager) Outdated() ([]Outdated, error) {
+	locals, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := m.listRemote(m.registry)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := m.Schedule()
+	if err != nil {
+		m.logger.Printf("%v, skip end-of-life check", err)
+	}
+
+	newest := map[string]string{}
+	for _, r := range remotes {
+		line := util.NodeLine(r.Version)
+		if v, ok := newest[line]; !ok || util.CompareNodeVer(r.Version, v) > 0 {
+			newest[line] = r.Version
+		}
+	}
+
+	result, now := []Outdated{}, time.Now()
+	for _, local := range locals {
+		ver, io, _, _, err := util.ParseNodeVer(local.Folder)
+		if err != nil || io || strings.Contains(ver, util.CHANNEL_SEP) || util.IsBuild(m.root, local.Folder) {
+			continue
+		}
+		line := util.NodeLine(ver)
+		o := Outdated{Folder: local.Folder, Version: ver, Latest: ver, Line: line}
+		if v, ok := newest[line]; ok && util.CompareNodeVer(v, ver) > 0 {
+			o.Latest = strings.TrimPrefix(v, "v")
+			o.Target = o.Latest + strings.TrimPrefix(local.Folder, ver)
+			for _, r := range remotes {
+				if r.Security && util.NodeLine(r.Version) == line && util.CompareNodeVer(r.Version, ver) > 0 {
+					o.Security = true
+				}
+			}
+		}
+		if l, ok := schedule[line]; ok {
+			o.End, o.EOL = l.End, l.EOL(now)
+		}
+		result = append(result, o)
+	}
+	return result, nil
+}
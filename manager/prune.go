@@ -0,0 +1,101 @@
+package manager
+
+import (
+	// go
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Prune policy, a version is removed when it not protected, not kept by Keep and KeepLatestPerMajor, and matches OlderThan and UnusedSince
+
+  - Keep:               keep the newest N versions, 0 is not keep
+  - KeepLatestPerMajor: keep the newest N versions of each major, 0 is not keep
+  - OlderThan:          only remove versions installed before, zero is not limit
+  - UnusedSince:        only remove versions not used by gnvm use since, zero is not limit
+  - Protect:            folders never remove, e.g. global latest and manifest versions
+*/
+type PrunePolicy struct {
+	Keep               int
+	KeepLatestPerMajor int
+	OlderThan          time.Time
+	UnusedSince        time.Time
+	Protect            []string
+}
+
+/*
+Prune candidate
+
+  - Folder:    folder name, e.g. x.xx.xx-x86
+  - Version:   Node.js version, e.g. 22.0.0-rc.1
+  - Size:      size on disk, bytes
+  - Installed: install date, from gnvm-source.json, otherwise node.exe modified time
+  - Used:      last used time, from gnvm-used.json, otherwise Installed
+*/
+type Pruned struct {
+	Folder    string    `json:"folder"`
+	Version   string    `json:"version"`
+	Size      int64     `json:"size"`
+	Installed time.Time `json:"installed"`
+	Used      time.Time `json:"used"`
+}
+
+/*
+Return local Node.js versions which removed by policy, sort by version desc, not remove anything
+
+Param:
+  - p: PrunePolicy
+
+Return:
+  - []Pruned
+  - error
+*/
+func (m *Manager) Prune(p PrunePolicy) ([]Pruned, error) {
+	locals, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	protect := map[string]bool{}
+	for _, folder := range p.Protect {
+		protect[folder] = true
+	}
+	if global, err := m.global(); err == nil {
+		protect[global] = true
+	}
+
+	all := []Pruned{}
+	for _, local := range locals {
+		version, _ := m.expect(local.Folder)
+		installed := m.installed(local.Folder)
+		used, err := util.ReadUsed(m.root, local.Folder)
+		if err != nil {
+			used = installed
+		}
+		all = append(all, Pruned{local.Folder, version, 0, installed, used})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return util.FormatNodeVer(strings.Split(all[i].Version, "-")[0]) > util.FormatNodeVer(strings.Split(all[j].Version, "-")[0])
+	})
+
+	candidates, majors := []Pruned{}, map[string]int{}
+	for i, v := range all {
+		major := strings.Split(v.Version, ".")[0]
+		majors[major]++
+		switch {
+		case protect[v.Folder]:
+		case i < p.Keep:
+		case majors[major] <= p.KeepLatestPerMajor:
+		case !p.OlderThan.IsZero() && !v.Installed.Before(p.OlderThan):
+		case !p.UnusedSince.IsZero() && !v.Used.Before(p.UnusedSince):
+		default:
+			v.Size, _ = util.DirSize(filepath.Join(m.root, v.Folder))
+			candidates = append(candidates, v)
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,230 @@
+package manager
+
+import (
+	// go
+	"bufio"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	// local
+	"gnvm/util"
+)
+
+/*
+Verify status of installed Node.js version, include:
+  - CHECK_OK:         node --version and SHA-256 of node.exe are ok
+  - CHECK_UNVERIFIED: node --version is ok, but not found any checksum, e.g. gnvm import
+  - CHECK_MISSING:    node.exe is not exist
+  - CHECK_VERSION:    node --version or arch not match folder name
+  - CHECK_CHECKSUM:   SHA-256 of node.exe not match SHASUMS256.txt or gnvm-source.json
+*/
+const (
+	CHECK_OK         = "ok"
+	CHECK_UNVERIFIED = "unverified"
+	CHECK_MISSING    = "missing"
+	CHECK_VERSION    = "version"
+	CHECK_CHECKSUM   = "checksum"
+)
+
+var sumsReg = regexp.MustCompile(`^(.*/v\d+\.\d+\.\d+[^/]*/)([^?#]+)$`)
+
+/*
+Verify result of installed Node.js version
+
+  - Folder:  folder name, global node.exe is util.GLOBAL
+  - Status:  include: CHECK_OK CHECK_UNVERIFIED CHECK_MISSING CHECK_VERSION CHECK_CHECKSUM
+  - Version: node --version without 'v', e.g. 20.1.0
+  - Arch:    node.exe arch, include: "x86" "x64"
+  - SHA256:  SHA-256 of node.exe
+  - Want:    expected SHA-256, when not found any checksum, it is ""
+  - From:    expected SHA-256 from, e.g. SHASUMS256.txt url, gnvm-source.json path
+*/
+type Check struct {
+	Folder  string `json:"folder"`
+	Status  string `json:"status"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+	SHA256  string `json:"sha256"`
+	Want    string `json:"want"`
+	From    string `json:"from"`
+}
+
+/*
+Return true when Status is CHECK_OK or CHECK_UNVERIFIED
+*/
+func (c *Check) OK() bool {
+	return c.Status == CHECK_OK || c.Status == CHECK_UNVERIFIED
+}
+
+/*
+Verify <root>/<folder>/node.exe, checksum usage registry SHASUMS256.txt first, then gnvm-source.json
+
+Param:
+  - folder: e.g. x.xx.xx x.xx.xx-x86 rc@22.0.0-rc.1 18.19.0-patched
+
+Return:
+  - *Check
+  - error: EXIT_NOT_INSTALLED when folder is not exist
+*/
+func (m *Manager) Verify(folder string) (*Check, error) {
+	dir := filepath.Join(m.root, folder)
+	if folder == "" || !util.IsDirExist(dir) {
+		return nil, util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist", folder)
+	}
+	check := &Check{Folder: folder}
+	if !util.IsDirExist(dir, util.NODE) {
+		check.Status = CHECK_MISSING
+		return check, nil
+	}
+	sum, err := util.HashFile(filepath.Join(dir, util.NODE))
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_ERROR, "hash %v Error: %v", filepath.Join(dir, util.NODE), err)
+	}
+	check.SHA256 = sum
+	check.Version, _ = util.GetNodeVer(dir)
+	check.Arch, _ = util.Arch(dir)
+
+	source, _ := util.ReadSource(m.root, folder)
+	url := ""
+	if source != nil {
+		url = source.URL
+	} else if !util.IsBuild(m.root, folder) {
+		// installed before gnvm-source.json, usage current registry
+		if ver, io, arch, _, err := util.ParseNodeVer(folder); err == nil {
+			_, url, _, _ = m.remote(ver, io, arch)
+		}
+	}
+	if check.Want, check.From = m.shasum(url); check.Want == "" && source != nil {
+		check.Want, check.From = source.SHA256, filepath.Join(dir, util.SOURCE)
+	}
+
+	version, arch := m.expect(folder)
+	switch {
+	case check.Want != "" && check.Want != check.SHA256:
+		check.Status = CHECK_CHECKSUM
+	case check.Version != version || arch != "" && check.Arch != arch:
+		check.Status = CHECK_VERSION
+	case check.Want == "":
+		check.Status = CHECK_UNVERIFIED
+	default:
+		check.Status = CHECK_OK
+	}
+	return check, nil
+}
+
+/*
+Verify <root>/node.exe, it must be the same as <root>/<folder>/node.exe
+
+Param:
+  - folder: global Node.js version folder, e.g. globalversion of .gnvmrc
+
+Return:
+  - *Check: Folder is util.GLOBAL, Want is SHA-256 of <root>/<folder>/node.exe
+  - error:  EXIT_NOT_INSTALLED when <root>/<folder>/node.exe is not exist
+*/
+func (m *Manager) VerifyGlobal(folder string) (*Check, error) {
+	want, err := util.HashFile(filepath.Join(m.root, folder, util.NODE))
+	if err != nil {
+		return nil, util.Errorf(util.EXIT_NOT_INSTALLED, "%v folder is not exist %v", folder, util.NODE)
+	}
+	check := &Check{Folder: util.GLOBAL, Want: want, From: filepath.Join(m.root, folder, util.NODE)}
+	if check.SHA256, err = util.HashFile(filepath.Join(m.root, util.NODE)); err != nil {
+		check.Status = CHECK_MISSING
+		return check, nil
+	}
+	check.Version, _ = util.GetNodeVer(m.root)
+	check.Arch, _ = util.Arch(m.root)
+	if check.Status = CHECK_OK; check.SHA256 != check.Want {
+		check.Status = CHECK_CHECKSUM
+	}
+	return check, nil
+}
+
+/*
+Redownload or reinstall <root>/<folder>, when fail, restore old folder
+
+Param:
+  - folder: see Verify, custom build usage gnvm-build.json from
+
+Return:
+  - error
+*/
+func (m *Manager) Repair(folder string) error {
+	dir, backup := filepath.Join(m.root, folder), filepath.Join(m.root, folder+".repair")
+	build, _ := util.ReadBuild(m.root, folder)
+	util.FileSystem.RemoveAll(backup)
+	if err := util.FileSystem.Rename(dir, backup); err != nil {
+		return util.Errorf(util.EXIT_ERROR, "move %v to %v Error: %v", dir, backup, err)
+	}
+	var err error
+	if build != nil {
+		_, err = m.InstallFrom(build.From, build.Name)
+	} else {
+		_, err = m.Install(folder)
+	}
+	if err != nil {
+		util.FileSystem.RemoveAll(dir)
+		util.FileSystem.Rename(backup, dir)
+		return err
+	}
+	util.FileSystem.RemoveAll(backup)
+	m.logger.Printf("repair %v success", folder)
+	return nil
+}
+
+/*
+Copy <root>/<folder>/node.exe to <root>/node.exe, usage when VerifyGlobal fail
+*/
+func (m *Manager) RepairGlobal(folder string) error {
+	if err := util.Copy(filepath.Join(m.root, folder), m.root, util.NODE); err != nil {
+		return util.Errorf(util.EXIT_ERROR, "copy %v to %v folder Error: %v", filepath.Join(m.root, folder), m.root, err)
+	}
+	return nil
+}
+
+/*
+Return expected node --version and arch of folder, arch is "" when unknown
+*/
+func (m *Manager) expect(folder string) (version, arch string) {
+	if build, err := util.ReadBuild(m.root, folder); err == nil {
+		return build.Version, build.Arch
+	}
+	ver, _, a, _, err := util.ParseNodeVer(folder)
+	if err != nil {
+		return folder, ""
+	}
+	if _, v, _, ok := util.ParseChannel(ver); ok {
+		ver = v
+	}
+	return ver, util.FormatArch(a)
+}
+
+/*
+Return SHA-256 of download url from <url folder>/SHASUMS256.txt, zip not support, because zip is removed after extract
+
+Return:
+  - sum:  when not found, return ""
+  - from: SHASUMS256.txt url
+*/
+func (m *Manager) shasum(url string) (string, string) {
+	arr := sumsReg.FindStringSubmatch(url)
+	if arr == nil || path.Ext(arr[2]) == ".zip" {
+		return "", ""
+	}
+	res, err := m.get(arr[1] + util.SHASUMS)
+	if err != nil {
+		m.logger.Printf("%v", err)
+		return "", ""
+	}
+	defer res.Body.Close()
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == arr[2] {
+			return strings.ToLower(fields[0]), arr[1] + util.SHASUMS
+		}
+	}
+	return "", ""
+}
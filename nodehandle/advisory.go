@@ -0,0 +1,86 @@
+package nodehandle
+
+import (
+	// go
+	"strings"
+	"time"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Cached advisory of current process, usage loadAdvisory
+*/
+var (
+	advisory *manager.Advisory
+	advised  bool
+)
+
+/*
+Return advisory from <root>/gnvm-advisory.json cache or remote, only fetch once, when get error, return nil
+
+Param:
+  - fetch: when false, only read cache, include stale cache, not access network
+*/
+func loadAdvisory(fetch bool) *manager.Advisory {
+	if !fetch && !advised {
+		if advisory == nil {
+			advisory = mgr.CachedAdvisory()
+		}
+		return advisory
+	}
+	if !advised {
+		advised = true
+		var err error
+		if advisory, err = mgr.Advisory(false); err != nil {
+			Verbose("get Node.js release schedule error, skip end-of-life and security check. Error: %v\n", err)
+		}
+	}
+	return advisory
+}
+
+/*
+Return advisory warning of local Node.js version, silence versions of config are skipped
+
+Param:
+  - folder: e.g. x.xx.xx x.xx.xx-x86
+  - fetch:  when false, only usage cache, see loadAdvisory
+
+Return:
+  - *manager.Warning: when not warning, return nil
+*/
+func advise(folder string, fetch bool) *manager.Warning {
+	for _, v := range config.Silenced() {
+		if v == folder || v == util.TrimArch(folder) {
+			return nil
+		}
+	}
+	if util.IsBuild(rootPath, folder) || loadAdvisory(fetch) == nil {
+		return nil
+	}
+	return advisory.Warn(folder, time.Now())
+}
+
+/*
+Print end-of-life and security release warning of local Node.js version, usage gnvm use and gnvm install
+
+Param:
+  - folder: e.g. x.xx.xx x.xx.xx-x86
+*/
+func warnAdvisory(folder string) {
+	w := advise(folder, true)
+	if w == nil {
+		return
+	}
+	silence := "gnvm config " + config.SILENCE + " " + strings.Join(append(config.Silenced(), folder), ",")
+	if w.EOL {
+		P(WARING, "Node.js %v line %v is end-of-life since %v, please upgrade. Silence by '%v'.\n", folder, w.Line, w.End, silence)
+	}
+	if w.Security != "" {
+		P(WARING, "Node.js %v has a newer security release %v, please use '%v'. Silence by '%v'.\n", folder, w.Security, "gnvm upgrade "+folder, silence)
+	}
+}
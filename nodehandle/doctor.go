@@ -0,0 +1,299 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/util"
+)
+
+/*
+Doctor check result level
+*/
+const (
+	PASS = "pass"
+	WARN = "warn"
+	FAIL = "fail"
+)
+
+/*
+Doctor check result
+
+  - Level:   include: PASS WARN FAIL
+  - Name:    check item, e.g. noderoot
+  - Message: check result
+  - Fix:     how to fix, when Level == PASS, Fix is ""
+*/
+type Check struct {
+	Level   string
+	Name    string
+	Message string
+	Fix     string
+}
+
+/*
+Check gnvm setup and print pass/warn/fail lines, include:
+  - noderoot path resolution
+  - .gnvmrc parse
+  - globalversion and node --version
+  - latestversion folder
+  - Path order
+  - NODE_HOME and noderoot
+  - npm version and global Node.js bundled npm version
+  - session environment variable
+  - registry reachability
+
+Return:
+  - err: when any check fail, return *util.ExitError
+*/
+func Doctor() (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			Error(ERROR, "'gnvm doctor' an error has occurred. please check. \nError: ", e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	fails := 0
+	for _, fn := range []func() []Check{checkRoot, checkConfig, checkGlobal, checkLatest, checkPath, checkNodeHome, checkNPM, checkSession, checkRegistry} {
+		for _, check := range fn() {
+			printCheck(check)
+			if check.Level == FAIL {
+				fails++
+			}
+		}
+	}
+	if fails > 0 {
+		return util.Errorf(util.EXIT_ERROR, "gnvm doctor found %v fail", fails)
+	}
+	return nil
+}
+
+func printCheck(check Check) {
+	cp := CP{FgColor: Green, Value: "[" + check.Level + "]"}
+	switch check.Level {
+	case WARN:
+		cp.FgColor = Yellow
+	case FAIL:
+		cp.FgColor = Red
+	}
+	P(DEFAULT, "%v %v: %v\n", cp, check.Name, check.Message)
+	if check.Fix != "" {
+		P(DEFAULT, "       fix: %v\n", check.Fix)
+	}
+}
+
+/*
+Check gnvm root path resolution, when resolution methods disagree, warn
+*/
+func checkRoot() []Check {
+	var checks []Check
+	name := "noderoot"
+	checks = append(checks, Check{PASS, name, tr("gnvm root is %v, resolved by %v", util.GlobalNodePath, util.RootSource), ""})
+
+	candidates := util.RootCandidates()
+	keys := make([]string, 0, len(candidates))
+	for k := range candidates {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == util.ROOT_CWD || k == util.ROOT_SESSION {
+			continue
+		}
+		if !samePath(candidates[k], util.GlobalNodePath) {
+			checks = append(checks, Check{WARN, name, tr("%v resolves to %v, but gnvm root is %v", k, candidates[k], util.GlobalNodePath),
+				tr("set %v or remove the other folder from Path", util.GNVM_HOME)})
+		}
+	}
+
+	if !util.IsDirExist(util.GlobalNodePath) {
+		checks = append(checks, Check{FAIL, name, tr("gnvm root %v folder is not exist", util.GlobalNodePath),
+			tr("create the folder or set %v to an exist folder", util.GNVM_HOME)})
+	}
+
+	if noderoot := config.GetConfig(config.NODEROOT); !samePath(noderoot, util.GlobalNodePath) {
+		checks = append(checks, Check{WARN, name, tr("config %v is %v, but gnvm root is %v", config.NODEROOT, noderoot, util.GlobalNodePath),
+			tr("use '%v'", "gnvm config set noderoot "+util.GlobalNodePath)})
+	}
+
+	if util.RootSource == util.ROOT_CWD {
+		checks = append(checks, Check{WARN, name, T("gnvm root is resolved by current folder, it changes with the working directory"),
+			tr("set environment variable %v or use '%v'", util.GNVM_HOME, "gnvm --root <path>")})
+	}
+	return checks
+}
+
+/*
+Format Check Message and Fix by current language, usage console catalog
+*/
+func tr(format string, a ...interface{}) string {
+	return fmt.Sprintf(T(format), a...)
+}
+
+func samePath(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if a == b || (runtime.GOOS == "windows" && strings.EqualFold(a, b)) {
+		return true
+	}
+	if x, err := filepath.EvalSymlinks(a); err == nil {
+		if y, err := filepath.EvalSymlinks(b); err == nil {
+			return x == y
+		}
+	}
+	return false
+}
+
+/*
+Check .gnvmrc parse and property value
+*/
+func checkConfig() []Check {
+	name := config.CONFIG
+	path, errs := config.Check()
+	if len(errs) == 0 {
+		return []Check{{PASS, name, tr("%v parse success", path), ""}}
+	}
+	var checks []Check
+	for _, err := range errs {
+		checks = append(checks, Check{FAIL, name, err.Error(), tr("use '%v' or '%v'", "gnvm config set <key> <value>", "gnvm config INIT")})
+	}
+	return checks
+}
+
+/*
+Check config globalversion and <root>/node.exe --version
+*/
+func checkGlobal() []Check {
+	name, global := config.GLOBAL_VERSION, config.GetConfig(config.GLOBAL_VERSION)
+	ver, err := util.GetNodeVer(rootPath)
+	if err != nil {
+		if global == util.UNKNOWN {
+			return []Check{{WARN, name, T("not found global node.exe"), tr("use '%v'", "gnvm install latest -g")}}
+		}
+		return []Check{{FAIL, name, tr("config %v is %v, but not found %v", name, global, rootPath+util.NODE), tr("use '%v'", "gnvm use "+global)}}
+	}
+	if bit, err := util.Arch(rootPath); err == nil && bit == "x86" && runtime.GOARCH == "amd64" {
+		ver += "-" + bit
+	}
+	if ver != global {
+		return []Check{{FAIL, name, tr("config %v is %v, but node --version is %v", name, global, ver), tr("use '%v'", "gnvm config set globalversion "+ver)}}
+	}
+	return []Check{{PASS, name, tr("%v matches node --version", global), ""}}
+}
+
+/*
+Check config latestversion folder exist
+*/
+func checkLatest() []Check {
+	name, latest := config.LATEST_VERSION, config.GetConfig(config.LATEST_VERSION)
+	if latest == util.UNKNOWN {
+		return []Check{{WARN, name, tr("latest version is %v", util.UNKNOWN), tr("use '%v'", "gnvm update latest")}}
+	}
+	if !util.IsDirExist(rootPath + latest + util.DIVIDE + util.NODE) {
+		return []Check{{FAIL, name, tr("%v folder is not exist %v", latest, util.NODE), tr("use '%v'", "gnvm install "+latest)}}
+	}
+	return []Check{{PASS, name, tr("%v folder exist", latest), ""}}
+}
+
+/*
+Check Path order, another node.exe earlier than gnvm root
+*/
+func checkPath() []Check {
+	name := "Path"
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || !util.IsDirExist(dir, util.NODE) {
+			continue
+		}
+		if samePath(dir, util.GlobalNodePath) {
+			return []Check{{PASS, name, tr("first %v in Path is %v", util.NODE, dir), ""}}
+		}
+		if env, ok := util.IsSessionEnv("", false); ok && samePath(dir, env) {
+			return []Check{{PASS, name, tr("first %v in Path is session %v", util.NODE, dir), ""}}
+		}
+		return []Check{{WARN, name, tr("another %v in %v is earlier than gnvm root %v", util.NODE, dir, util.GlobalNodePath),
+			tr("move %v before %v in Path, or remove it", util.GlobalNodePath, dir)}}
+	}
+	return []Check{{WARN, name, tr("not found %v in Path", util.NODE), tr("use '%v'", "gnvm reg noderoot")}}
+}
+
+/*
+Check environment variable NODE_HOME and config noderoot
+*/
+func checkNodeHome() []Check {
+	noderoot, nodehome := config.GetConfig(config.NODEROOT), os.Getenv(NODE_HOME)
+	if nodehome == "" {
+		return []Check{{WARN, NODE_HOME, T("environment variable is not set"), tr("use '%v'", "gnvm reg noderoot")}}
+	}
+	if !samePath(nodehome, noderoot) {
+		return []Check{{WARN, NODE_HOME, tr("%v is %v, but config %v is %v", NODE_HOME, nodehome, config.NODEROOT, noderoot), tr("use '%v'", "gnvm reg noderoot")}}
+	}
+	return []Check{{PASS, NODE_HOME, tr("%v matches config %v", nodehome, config.NODEROOT), ""}}
+}
+
+/*
+Check local npm version and global Node.js bundled npm version
+*/
+func checkNPM() (checks []Check) {
+	name := util.NPM
+	local, err := localNPMVer()
+	if err != nil {
+		return []Check{{WARN, name, tr("not found npm in %v", rootPath), tr("use '%v'", "gnvm npm global")}}
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			checks = []Check{{WARN, name, tr("local npm version is %v, get Node.js bundled npm version fail, Error: %v", local, err), ""}}
+		}
+	}()
+
+	if bundled := getNodeNpmVer(); bundled != local {
+		return []Check{{WARN, name, tr("local npm version is %v, but global Node.js bundled npm version is %v", local, bundled), tr("use '%v'", "gnvm npm global")}}
+	}
+	return []Check{{PASS, name, tr("npm %v matches global Node.js bundled npm", local), ""}}
+}
+
+/*
+Check session environment variable GNVM_SESSION_NODE_HOME
+*/
+func checkSession() []Check {
+	name := "session"
+	env, ok := util.IsSessionEnv("", false)
+	if !ok {
+		return []Check{{PASS, name, T("not in session environment"), ""}}
+	}
+	if !util.IsDirExist(env, util.NODE) {
+		return []Check{{FAIL, name, tr("GNVM_SESSION_NODE_HOME is %v, but folder is not exist %v", env, util.NODE), tr("use '%v'", "gns clear")}}
+	}
+	if !util.IsDirExist(GNS_HOME) {
+		return []Check{{WARN, name, tr("GNVM_SESSION_NODE_HOME is %v, but %v is not exist", env, GNS_HOME), tr("use '%v'", "gns clear")}}
+	}
+	return []Check{{WARN, name, tr("current is session environment %v, some commands are disabled", env), tr("use '%v'", "gns clear")}}
+}
+
+/*
+Check registry index.json reachability
+*/
+func checkRegistry() []Check {
+	name, url := config.REGISTRY, config.GetConfig(config.REGISTRY)+util.NODELIST
+	client := &http.Client{Transport: util.HTTPClient.Transport, Timeout: config.Load().Timeout}
+	res, err := client.Head(url)
+	if err != nil {
+		return []Check{{FAIL, name, tr("%v unreachable, Error: %v", url, err.Error()), tr("use '%v' or '%v'", "gnvm config registry TAOBAO", "gnvm config set proxy <url>")}}
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return []Check{{FAIL, name, tr("%v response code is %v", url, res.StatusCode), tr("use '%v'", "gnvm config registry test")}}
+	}
+	return []Check{{PASS, name, tr("%v reachable", url), ""}}
+}
@@ -0,0 +1,78 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+	"os"
+	"path/filepath"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Export lockfile of installed Node.js versions, or verify current machine by lockfile
+
+Param:
+  - path:   lockfile path, when path == "", usage gnvm-lock.json of current folder
+  - verify: when true, compare current machine with lockfile, not write
+
+Return:
+  - err: *util.ExitError, util.EXIT_CHECKSUM when current machine is different from lockfile
+*/
+func Export(path string, verify bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm export %v' an error has occurred. please check. \nError: ", path)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	if path == "" {
+		dir, _ := os.Getwd()
+		path = filepath.Join(dir, manager.LOCKFILE)
+	}
+	lock, err := mgr.Lock()
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help export")
+	}
+	lock.Latest = config.GetConfig(config.LATEST_VERSION)
+	lock.NPM, _ = localNPMVer()
+
+	if !verify {
+		if err := manager.WriteLock(path, lock); err != nil {
+			return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help export")
+		}
+		P(DEFAULT, "Export %v Node.js versions to %v.\n", len(lock.Versions), path)
+		return nil
+	}
+
+	want, err := manager.ReadLock(path)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help export")
+	}
+	drifts := manager.CompareLock(want, lock)
+	for _, d := range drifts {
+		switch {
+		case d.Field == "missing":
+			P(WARING, "%v is in lockfile, but not installed.\n", d.Folder)
+		case d.Field == "extra":
+			P(WARING, "%v is installed, but not in lockfile.\n", d.Folder)
+		case d.Folder == "":
+			P(WARING, "%v is %v, lockfile is %v.\n", d.Field, d.Got, d.Want)
+		default:
+			P(WARING, "%v %v is %v, lockfile is %v.\n", d.Folder, d.Field, d.Got, d.Want)
+		}
+	}
+	if len(drifts) > 0 {
+		return util.Fail(util.EXIT_CHECKSUM, ERROR, "verify fail, found %v differences from %v.\n", len(drifts), path)
+	}
+	P(DEFAULT, "Verify success, %v Node.js versions are the same as %v.\n", len(lock.Versions), path)
+	return nil
+}
@@ -0,0 +1,110 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Reinstall global npm packages of from Node.js version to to Node.js version, usage npm of to
+
+Param:
+  - from:   include: global latest x.xx.xx x.xx.xx-x86
+  - to:     the same as from
+  - latest: when true, install latest versions, otherwise pinned versions
+
+Return:
+  - err: *util.ExitError
+*/
+func MigrateGlobals(from, to string, latest bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm migrate-globals %v %v' an error has occurred. please check. \nError: ", from, to)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	src, err := mgr.Resolve(from)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help migrate-globals")
+	}
+	dst, err := mgr.Resolve(to)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help migrate-globals")
+	}
+	if src == dst {
+		return util.Fail(util.EXIT_USAGE, ERROR, "%v and %v are the same Node.js version %v. See '%v'.\n", from, to, src, "gnvm help migrate-globals")
+	}
+	if !util.IsDirExist(rootPath, dst, util.NODE) {
+		return util.Fail(util.EXIT_NOT_INSTALLED, ERROR, "%v folder is not exist %v, use '%v' get local Node.js version list. See '%v'.\n", dst, util.NODE, "gnvm ls", "gnvm help migrate-globals")
+	}
+	packages, err := mgr.Globals(src)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm ls")
+	}
+	return installGlobals(src, dst, packages, latest)
+}
+
+/*
+Install Node.js version, then reinstall global npm packages of from Node.js version, usage gnvm install --reinstall-packages-from
+
+Param:
+  - version: install Node.js version, see InstallNode
+  - from:    include: global latest x.xx.xx x.xx.xx-x86
+  - global:  when global == true, call Use func before reinstall, global npm prefix is <root>
+  - latest:  when true, install latest versions, otherwise pinned versions
+
+Return:
+  - err: *util.ExitError
+*/
+func InstallWithGlobals(version, from string, global, latest bool) (err error) {
+	src, err := mgr.Resolve(from)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help install")
+	}
+
+	// read packages before install, global node.exe maybe changed
+	packages, err := mgr.Globals(src)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm ls")
+	}
+	if err = InstallNode([]string{version}, false); err != nil {
+		return err
+	}
+	dst, err := mgr.Resolve(version)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help install")
+	}
+	if global {
+		if err = Use(dst); err != nil {
+			return err
+		}
+		config.SetConfig(config.GLOBAL_VERSION, dst)
+	}
+	return installGlobals(src, dst, packages, latest)
+}
+
+func installGlobals(src, dst string, packages []manager.Package, latest bool) error {
+	if len(packages) == 0 {
+		P(DEFAULT, "%v not have any global npm package, not need to migrate.\n", src)
+		return nil
+	}
+	P(DEFAULT, "Reinstall %v global npm packages of %v with npm of %v, please wait.\n", len(packages), src, dst)
+	for _, pkg := range packages {
+		P(DEFAULT, "  + %v\n", pkg.Spec(latest))
+	}
+	if err := mgr.InstallGlobals(dst, packages, latest); err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help npm")
+	}
+	P(DEFAULT, "Migrate success, %v global npm packages are installed to %v.\n", len(packages), dst)
+	return nil
+}
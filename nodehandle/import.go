@@ -0,0 +1,72 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Import Node.js versions of other version manager, and set default version to global Node.js version
+
+Param:
+  - tool: include: nvm-windows nvm nodist fnm
+  - dir:  version manager install path, when dir == "", usage manager.ImportPath
+  - mode: include: copy move link
+
+Return:
+  - err: *util.ExitError
+*/
+func Import(tool, dir, mode string) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm import --from %v' an error has occurred. please check. \nError: ", tool)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	if dir == "" {
+		dir = manager.ImportPath(tool)
+	}
+	foreigns, err := manager.Scan(tool, dir)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help import")
+	}
+	if len(foreigns) == 0 {
+		return util.Fail(util.EXIT_NOT_INSTALLED, WARING, "not found any Node.js version of %v in %v.\n", tool, dir)
+	}
+	P(NOTICE, "found %v Node.js versions of %v in %v.\n", len(foreigns), tool, dir)
+
+	def := ""
+	for _, f := range foreigns {
+		if util.IsDirExist(rootPath, f.Folder(), util.NODE) {
+			P(WARING, "%v folder exist, skip %v.\n", f.Folder(), f.Exec)
+		} else if _, e := mgr.Import(f, mode); e != nil {
+			err = util.Fail(util.ExitCode(e), ERROR, "%v.\n", e.Error())
+			continue
+		} else {
+			P(DEFAULT, "Import %v success, %v from %v.\n", f.Folder(), mode, f.Exec)
+		}
+		if f.Default {
+			def = f.Folder()
+		}
+	}
+
+	// carry over default version of version manager
+	if def != "" {
+		P(NOTICE, "%v default version is %v.\n", tool, def)
+		if e := Use(def); e != nil {
+			return e
+		}
+		config.SetConfig(config.GLOBAL_VERSION, def)
+	}
+	return err
+}
@@ -3,28 +3,62 @@ package nodehandle
 import (
 
 	// lib
-	. "github.com/Kenshin/cprint"
 	"github.com/Kenshin/curl"
 
 	// go
 	//"log"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	// local
 	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
 	"gnvm/util"
 )
 
-var rootPath, latURL string
+var rootPath string
 
-func init() {
+var mgr *manager.Manager
+
+/*
+Initialize nodehandle, must be call after config.Init()
+
+Return:
+  - error: create manager.Manager error
+*/
+func Init() (err error) {
 	rootPath = util.GlobalNodePath + util.DIVIDE
-	latURL = config.GetConfig(config.REGISTRY) + util.LATEST + "/" + util.SHASUMS
+	GNS_HOME = util.GlobalNodePath + util.DIVIDE + "gns.cmd"
+	mgr, err = manager.New(manager.Options{Root: util.GlobalNodePath, Registry: config.GetConfig(config.REGISTRY), Channels: config.Channels(), Schedule: config.GetConfig(config.SCHEDULE), Logger: verbose{}, Progress: progress})
+	advisory, advised = nil, false
+	Verbose("gnvm root is %v, resolve by %v.\n", util.GlobalNodePath, util.RootSource)
+	Verbose("registry is %v.\n", config.GetConfig(config.REGISTRY))
+	initReg()
+	return err
+}
+
+/*
+manager.Logger usage VERBOSE level
+*/
+type verbose struct{}
+
+func (verbose) Printf(format string, v ...interface{}) {
+	Verbose("%v.\n", fmt.Sprintf(format, v...))
+}
+
+/*
+Return remote latest SHASUMS256.txt url, usage current registry( include --registry and GNVM_REGISTRY )
+*/
+func latURL() string {
+	return config.GetConfig(config.REGISTRY) + util.LATEST + "/" + util.SHASUMS
 }
 
 /**
@@ -37,201 +71,263 @@ func init() {
  * newerPath   : newer node.exe version path,  e.g. <rootPath>\x.xx.xx\
  *
  */
-func Use(newer string) bool {
+func Use(newer string) (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm use %v' an error has occurred. please check. \nError: ", newer)
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	// get true folder, e.g. folder is latest return x.xx.xx
 	util.FormatLatVer(&newer, config.GetConfig(config.LATEST_VERSION), true)
 	if newer == util.UNKNOWN {
-		P(WARING, "current latest is %v, please usage '%v' first. See '%v'.\n", newer, "gnvm update latest", "gnvm help update")
-		return false
+		return util.Fail(util.EXIT_NOT_INSTALLED, WARING, "current latest is %v, please usage '%v' first. See '%v'.\n", newer, "gnvm update latest", "gnvm help update")
 	}
 
 	// set newerPath and verify newerPath is exist?
 	newerPath := rootPath + newer
 	if _, err := util.GetNodeVer(newerPath); err != nil {
-		P(WARING, "%v folder is not exist %v, use '%v' get local Node.js version list. See '%v'.\n", newer, "node.exe", "gnvm ls", "gnvm help ls")
-		return false
+		return util.Fail(util.EXIT_NOT_INSTALLED, WARING, "%v folder is not exist %v, use '%v' get local Node.js version list. See '%v'.\n", newer, "node.exe", "gnvm ls", "gnvm help ls")
 	}
 
 	// get <root>/node.exe version, when exist, get full version, e.g. x.xx.xx-x86
-	global, err := util.GetNodeVer(rootPath)
+	global, err := mgr.Resolve(util.GLOBAL)
 	if err != nil {
 		P(WARING, "not found %v Node.js version.\n", "global")
-	} else {
-		if bit, err := util.Arch(rootPath); err == nil {
-			if bit == "x86" && runtime.GOARCH == "amd64" {
-				global += "-" + bit
-			}
-		}
 	}
 
 	// check newer is global
 	if newer == global {
 		P(WARING, "current Node.js version is %v, not re-use. See '%v'.\n", newer, "gnvm node-version")
-		return false
+		return nil
 	}
 
-	// set globalPath
-	globalPath := rootPath + global
-
-	// <root>/global is exist? when not exist, create global folder
-	if !util.IsDirExist(globalPath) {
-		if err := os.Mkdir(globalPath, 0777); err != nil {
-			P(ERROR, "create %v folder Error: %v.\n", global, err.Error())
-			return false
-		}
-	}
-
-	// backup copy <root>/node.exe to <root>/global/node.exe
-	if global != "" {
-		if err := util.Copy(rootPath, globalPath, util.NODE); err != nil {
-			P(ERROR, "copy %v to %v folder Error: %v.\n", rootPath, globalPath, err.Error())
-			return false
-		}
-	}
-
-	// copy <root>/newer/node.exe to <root>/node.exe
-	if err := util.Copy(newerPath, rootPath, util.NODE); err != nil {
-		P(ERROR, "copy %v to %v folder Error: %v.\n", newerPath, rootPath, err.Error())
-		return false
+	// backup <root>/node.exe to <root>/global/node.exe, copy <root>/newer/node.exe to <root>/node.exe
+	if _, err := mgr.Use(newer); err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v.\n", err.Error())
 	}
 
 	P(DEFAULT, "Set success, global Node.js version is %v.\n", newer)
+	warnAdvisory(newer)
 
-	return true
+	return nil
 }
 
 /*
 Install node
 
 Param:
-  - args  : install Node.js versions, include: x.xx.xx latest x.xx.xx-io-x86 x.xx.xx-x86
+  - args  : install Node.js versions, include: x.xx.xx latest x.xx.xx-io-x86 x.xx.xx-x86 <channel> <channel>/x.xx.xx-<tag>
   - global: when global == true, call Use func.
 
 Return:
-  - code: dl[0].Code, usage 'gnvm update latest'
+  - err: *util.ExitError, e.g. util.EXIT_NETWORK when download fail
 */
-func InstallNode(args []string, global bool) int {
+func InstallNode(args []string, global bool) (err error) {
 
-	localVersion, isLatest, code, dl, ts := "", false, 0, new(curl.Download), new(curl.Task)
+	localVersion, latest, folders := "", "", []string{}
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
-			if strings.HasPrefix(err.(string), "CURL Error:") {
-				fmt.Printf("\n")
-			}
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm install %v' an error has occurred. \nError: ", strings.Join(args, " "))
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	for _, v := range args {
-		ver, io, arch, suffix, err := util.ParseNodeVer(v)
-		if err != nil {
-			switch err.Error() {
-			case "1":
-				P(ERROR, "%v not node.exe download.\n", v)
-			case "2":
-				P(ERROR, "%v format error, suffix only must be '%v' or '%v'.\n", v, "x86", "x64")
-			case "3":
-				P(ERROR, "%v format error, parameter must be '%v' or '%v'.\n", v, "x.xx.xx", "x.xx.xx-x86|x64")
-			case "4":
-				P(ERROR, "%v not an %v Node.js version.\n", v, "valid")
-			case "5":
+		ver, _, _, suffix, e := util.ParseNodeVer(v)
+		if e != nil {
+			err = e
+			if errors.Is(e, util.ErrNPMVersion) {
 				P(WARING, "'%v' command is no longer supported. See '%v'.\n", "gnvm install npm", "gnvm help npm")
+			} else {
+				P(ERROR, "%v. See '%v'.\n", e.Error(), "gnvm help install")
 			}
 			continue
 		}
 
 		// when os is 386, not download 64 bit node.exe
 		if runtime.GOARCH == "386" && suffix == "x64" {
-			P(WARING, "current operating system is %v, not support %v suffix.\n", "32-bit", "x64")
+			err = util.Fail(util.EXIT_USAGE, WARING, "current operating system is %v, not support %v suffix.\n", "32-bit", "x64")
 			continue
 		}
 
-		// check local latest and get remote latest
-		v = util.EqualAbs("latest", v)
+		// check local latest
 		if ver == util.LATEST {
 			localVersion = config.GetConfig(config.LATEST_VERSION)
 			P(NOTICE, "local  latest version is %v.\n", localVersion)
-
-			version := util.GetLatVer(latURL)
-			if version == "" {
-				P(ERROR, "get latest version error, please check. See '%v'.\n", "gnvm config help")
-				break
-			}
-
-			isLatest = true
-			ver = version
-			P(NOTICE, "remote latest version is %v.\n", version)
-		} else {
-			isLatest = false
 		}
 
-		// ture folder name
-		if suffix != "" {
-			ver += "-" + suffix
+		// get remote latest and channel version, e.g. nightly to nightly@22.0.0-nightly20240101abcdef
+		channel, version, _, isChannel := util.ParseChannel(v)
+		folder, e := mgr.Resolve(v)
+		switch {
+		case e != nil && ver == util.LATEST:
+			err = util.Fail(util.EXIT_NETWORK, ERROR, "get latest version error, please check. See '%v'.\n", "gnvm config help")
+		case e != nil && isChannel && version == util.LATEST && util.ExitCode(e) == util.EXIT_NETWORK:
+			err = util.Fail(util.EXIT_NETWORK, ERROR, "get %v latest version error, please check. See '%v'.\n", channel, "gnvm ls -r --channel "+channel)
+		case e != nil:
+			err = util.Fail(util.ExitCode(e), ERROR, "%v. See '%v'.\n", e.Error(), "gnvm help install")
+		case ver == util.LATEST:
+			latest = folder
+			P(NOTICE, "remote latest version is %v.\n", util.TrimArch(folder))
+		case isChannel && version == util.LATEST:
+			P(NOTICE, "remote %v latest version is %v.\n", channel, util.TrimArch(strings.TrimPrefix(folder, channel+util.CHANNEL_SEP)))
+		}
+		if e != nil {
+			continue
 		}
 
 		// verify <root>/folder is exist
-		folder := rootPath + ver
-		if _, err := util.GetNodeVer(folder); err == nil {
-			P(WARING, "%v folder exist.\n", ver)
+		if _, e := util.GetNodeVer(rootPath + folder); e == nil {
+			P(WARING, "%v folder exist.\n", folder)
 			continue
 		}
+		folders = append(folders, folder)
+	}
 
-		// get and set url( include iojs)
-		url := config.GetConfig(config.REGISTRY)
-		if io {
-			url = config.GetIOURL(url)
+	// downlaod
+	if len(folders) == 0 {
+		return err
+	}
+	P(DEFAULT, "Start download Node.js versions [%v].\n", strings.Join(folders, ", "))
+	errs := installAll(folders)
+	for i, folder := range folders {
+		if e := errs[i]; e != nil {
+			help := "gnvm help install"
+			if util.ExitCode(e) == util.EXIT_NOT_INSTALLED {
+				help = "gnvm ls -r -d"
+			}
+			err = util.Fail(util.ExitCode(e), ERROR, "%v. See '%v'.\n", e.Error(), help)
+			continue
 		}
 
-		// add task
-		if url, err := util.GetRemoteNodePath(url, ver, arch); err == nil {
-			dl.AddTask(ts.New(url, ver, util.NODE, folder))
+		// global version warn by Use
+		if !global || len(args) != 1 {
+			warnAdvisory(folder)
+		}
+		if folder == latest && folder != localVersion {
+			config.SetConfig(config.LATEST_VERSION, folder)
+			P(DEFAULT, "Set success, %v new value is %v\n", config.LATEST_VERSION, folder)
+		}
+		if global && len(args) == 1 {
+			if err = Use(folder); err == nil {
+				config.SetConfig(config.GLOBAL_VERSION, folder)
+			}
 		}
 	}
+	P(DEFAULT, "End download.\n")
 
-	// downlaod
-	if len(*dl) > 0 {
-		curl.Options.Header = false
-		arr := (*dl).GetValues("Title")
-		P(DEFAULT, "Start download Node.js versions [%v].\n", strings.Join(arr, ", "))
-		newDL, errs := curl.New(*dl)
-		for _, task := range newDL {
-			v := strings.Replace(task.Dst, rootPath, "", -1)
-			if v != localVersion && isLatest {
-				config.SetConfig(config.LATEST_VERSION, v)
-				P(DEFAULT, "Set success, %v new value is %v\n", config.LATEST_VERSION, v)
-			}
-			if global && len(args) == 1 {
-				if ok := Use(v); ok {
-					config.SetConfig(config.GLOBAL_VERSION, v)
-				}
-			}
+	return err
+}
+
+/*
+Install Node.js versions in parallel, usage mgr.Install
+
+Param:
+  - folders: Node.js folder names, e.g. 20.1.0 18.16.0-x86
+
+Return:
+  - errs: install error of each folder, nil when success
+*/
+func installAll(folders []string) []error {
+	errs, wg := make([]error, len(folders)), sync.WaitGroup{}
+	atomic.StoreInt32(&tasks, int32(len(folders)))
+	defer atomic.StoreInt32(&tasks, 0)
+	for i, folder := range folders {
+		wg.Add(1)
+		go func(i int, folder string) {
+			defer wg.Done()
+			_, errs[i] = mgr.Install(folder)
+		}(i, folder)
+	}
+	wg.Wait()
+	return errs
+}
+
+// running download tasks of installAll, when > 1, '\r' progress line will overwrite each other
+var tasks int32
+
+/*
+manager.Options Progress, print download progress, e.g. 20.1.0: 45% 13.2 MB/29.4 MB
+when parallel download, only print finish line, e.g. 20.1.0: 100% 29.4 MB
+*/
+func progress(name string, done, total int64) {
+	if Level == LEVEL_QUIET || !util.IsText() {
+		return
+	}
+	if total <= 0 {
+		P(DEFAULT, "%v: %v\n", name, util.FormatSize(done))
+		return
+	}
+	if atomic.LoadInt32(&tasks) > 1 {
+		if done >= total {
+			P(DEFAULT, "%v: %v %v\n", name, "100%", util.FormatSize(total))
 		}
-		if len(errs) > 0 {
-			code = (*dl)[0].Code
-			s := ""
-			for _, v := range errs {
-				s += v.Error()
-			}
-			P(WARING, s)
+		return
+	}
+	P(DEFAULT, "\r%v: %v %v/%v", name, fmt.Sprintf("%3d%%", done*100/total), util.FormatSize(done), util.FormatSize(total))
+	if done >= total {
+		P(DEFAULT, "\n")
+	}
+}
+
+/*
+Install custom Node.js build from local archive or url
+
+Param:
+  - from:   local path or url, e.g. ./node-v18.19.0-win-x64.zip https://internal/builds/node-custom.zip
+  - name:   local folder name, e.g. 18.19.0-patched, when name == "", get from archive name
+  - global: when global == true, call Use func.
+
+Return:
+  - err: *util.ExitError
+*/
+func InstallFrom(from, name string, global bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm install --from %v' an error has occurred. \nError: ", from)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	P(DEFAULT, "Start install Node.js build from %v, please wait.\n", from)
+	build, err := mgr.InstallFrom(from, name)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help install")
+	}
+	P(DEFAULT, "Node.js build %v install success, node.exe version is %v, arch is %v.\n", build.Name, build.Version, build.Arch)
+
+	if global {
+		if err = Use(build.Name); err == nil {
+			config.SetConfig(config.GLOBAL_VERSION, build.Name)
 		}
 	}
+	return err
+}
 
-	return code
+/*
+Return <url>/index.json Nodist from cache, when get error, return nil
+*/
+func index(indexes map[string]*Nodist, url string) *Nodist {
+	nodist, ok := indexes[url]
+	if !ok {
+		var err error
+		if nodist, err, _ = New(url+util.NODELIST, nil); err != nil {
+			Verbose("get %v error, usage default download url. Error: %v\n", url+util.NODELIST, err)
+		}
+		indexes[url] = nodist
+	}
+	return nodist
 }
 
 /*
@@ -240,38 +336,28 @@ Uninstall node and npm
 Param:
   - folder: version
 */
-func Uninstall(folder string) {
+func Uninstall(folder string) (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("gnvm uninstall %v an error has occurred. please check your input. \nError: ", folder)
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
-	// set removePath
-	removePath := rootPath + folder
-
 	if folder == util.UNKNOWN {
-		P(ERROR, "current latest version is %v, please usage '%v' first. See '%v'.\n", folder, "gnvm update latest", "gnvm help update")
-		return
-	}
-
-	// rootPath/version is exist
-	if util.IsDirExist(removePath) != true {
-		P(ERROR, "%v folder is not exist. See '%v'.\n", folder, "gnvm ls")
-		return
+		return util.Fail(util.EXIT_NOT_INSTALLED, ERROR, "current latest version is %v, please usage '%v' first. See '%v'.\n", folder, "gnvm update latest", "gnvm help update")
 	}
 
 	// remove rootPath/version folder
-	if err := os.RemoveAll(removePath); err != nil {
-		P(ERROR, "uninstall %v fail, Error: %v.\n", folder, err.Error())
-		return
+	if err := mgr.Uninstall(folder); err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm ls")
 	}
 
 	P(DEFAULT, "Node.js version %v uninstall success.\n", folder)
+	return nil
 }
 
 /*
@@ -283,23 +369,23 @@ Update local Node.js latest verion
 Param:
   - global: when global == true, call Use func.
 */
-func Update(global bool) {
+func Update(global bool) (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'%v' an error has occurred. \nError: ", "gnvm updte latest")
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
-	localVersion, remoteVersion := config.GetConfig(config.LATEST_VERSION), util.GetLatVer(latURL)
+	localVersion := config.GetConfig(config.LATEST_VERSION)
+	remoteVersion, e := mgr.Resolve(util.LATEST)
 
 	P(NOTICE, "local  Node.js latest version is %v.\n", localVersion)
-	if remoteVersion == "" {
-		P(ERROR, "get latest version error, please check. See '%v'.\n", "gnvm help config")
-		return
+	if e != nil {
+		return util.Fail(util.EXIT_NETWORK, ERROR, "get latest version error, please check. See '%v'.\n", "gnvm help config")
 	}
 	P(NOTICE, "remote Node.js latest version is %v from %v.\n", remoteVersion, config.GetConfig("registry"))
 
@@ -307,7 +393,7 @@ func Update(global bool) {
 
 	switch {
 	case localVersion == util.UNKNOWN:
-		if code := InstallNode(args, global); code == 0 {
+		if err = InstallNode(args, global); err == nil {
 			config.SetConfig(config.LATEST_VERSION, remoteVersion)
 			P(DEFAULT, "Update Node.js latest success, current latest version is %v.\n", remoteVersion)
 		}
@@ -316,13 +402,13 @@ func Update(global bool) {
 			cp := CP{Red, false, None, false, "="}
 			P(DEFAULT, "Remote latest version %v %v latest version %v, don't need to upgrade.\n", remoteVersion, cp, localVersion)
 			if global {
-				if ok := Use(localVersion); ok {
+				if err = Use(localVersion); err == nil {
 					config.SetConfig(config.GLOBAL_VERSION, localVersion)
 				}
 			}
 		} else {
 			P(WARING, "%v folder is not exist. See '%v'.\n", localVersion, "gnvm ls")
-			if code := InstallNode(args, global); code == 0 {
+			if err = InstallNode(args, global); err == nil {
 				P(DEFAULT, "Local Node.js latest version is %v.\n", localVersion)
 			}
 		}
@@ -332,11 +418,12 @@ func Update(global bool) {
 	case local < remote:
 		cp := CP{Red, false, None, false, ">"}
 		P(WARING, "remote latest version %v %v local latest version %v.\n", remoteVersion, cp, localVersion)
-		if code := InstallNode(args, global); code == 0 {
+		if err = InstallNode(args, global); err == nil {
 			config.SetConfig(config.LATEST_VERSION, remoteVersion)
 			P(DEFAULT, "Update success, Node.js latest version is %v.\n", remoteVersion)
 		}
 	}
+	return err
 }
 
 /*
@@ -345,50 +432,73 @@ Search Node.js version and Print
 Param:
   - s: Node.js version, inlcude: *.*.* 0.*.* 0.10.* /<regexp>/ latest 0.10.10
 */
-func Search(s string) {
-	regex, err := util.FormatWildcard(s, latURL)
+func Search(s string) (err error) {
+	regex, err := util.FormatWildcard(s, latURL())
 	if err != nil {
-		P(ERROR, "%v not an %v Node.js version.\n", s, "valid")
-		return
+		P(ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help search")
+		return err
 	}
 
 	// set url
-	url := config.GetConfig(config.REGISTRY)
+	io := false
 	if arr := strings.Split(s, "."); len(arr) == 3 {
 		if ver, _ := strconv.Atoi(arr[0]); ver >= 1 && ver <= 3 {
-			url = config.GetIOURL(url)
+			io = true
 		}
 	}
+	url, _ := mgr.Channel("", io)
 	url += util.NODELIST
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'%v' an error has occurred. please check your input.\nError: ", "gnvm search")
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	// print
-	P(DEFAULT, "Search Node.js version rules [%v] from %v, please wait.\n", s, url)
+	if util.IsText() {
+		P(DEFAULT, "Search Node.js version rules [%v] from %v, please wait.\n", s, url)
+	}
 
 	// generate nodist
 	nodist, err, code := New(url, regex)
 	if err != nil {
 		if code == -1 {
-			P(ERROR, "'%v' get url %v error, Error: %v\n", "gnvm search", url, err)
-		} else {
-			P(ERROR, "%v an error has occurred. please check. Error: %v\n", "gnvm search", err)
+			return util.Fail(util.EXIT_NETWORK, ERROR, "'%v' get url %v error, Error: %v\n", "gnvm search", url, err)
 		}
-		return
+		return util.Fail(util.EXIT_ERROR, ERROR, "%v an error has occurred. please check. Error: %v\n", "gnvm search", err)
 	}
 
-	if len(nodist.nl) > 0 {
+	if len(nodist.nl) > 0 || !util.IsText() {
 		nodist.Detail(0)
 	} else {
 		P(WARING, "not search any Node.js version details, use rules [%v] from %v.\n", s, url)
 	}
+	return nil
+}
+
+/*
+Structured local Node.js version, usage --json and --format=tsv, long fields only usage gnvm ls -L
+
+EOL and Security are advisory warnings, see manager.Warning
+*/
+type Local struct {
+	Version   string `json:"version"`
+	Arch      string `json:"arch"`
+	Global    bool   `json:"global"`
+	Latest    bool   `json:"latest"`
+	Path      string `json:"path"`
+	Size      int64  `json:"size,omitempty"`
+	Installed string `json:"installed,omitempty"`
+	Registry  string `json:"registry,omitempty"`
+	NPM       string `json:"npm,omitempty"`
+	LTS       string `json:"lts,omitempty"`
+	Used      string `json:"used,omitempty"`
+	EOL       bool   `json:"eol,omitempty"`
+	Security  string `json:"security,omitempty"`
 }
 
 /*
@@ -396,20 +506,22 @@ Print current local Node.js version list
 
 Param:
   - isPrint: when isPrint == true, print console
+  - long:    when long == true, print arch, size, install date, registry, npm, lts, last used and total disk usage
 */
-func LS(isPrint bool) ([]string, error) {
+func LS(isPrint, long bool) (lsArr []string, err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
-			Error(ERROR, "'gnvm ls' an error has occurred. please check. \nError: ", err)
-			os.Exit(0)
+		if e := recover(); e != nil {
+			Error(ERROR, "'gnvm ls' an error has occurred. please check. \nError: ", e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
-	var lsArr []string
-	existVersion := false
-	files, err := os.ReadDir(rootPath)
+	var locals []Local
+	var descs []string
+	existVersion, warns := false, 0
+	list, err := mgr.List()
 
 	// show error
 	if err != nil {
@@ -417,104 +529,275 @@ func LS(isPrint bool) ([]string, error) {
 		return lsArr, err
 	}
 
-	P(NOTICE, "gnvm.exe root is %v \n", rootPath)
-	for _, file := range files {
+	if isPrint && util.IsText() {
+		P(NOTICE, "gnvm.exe root is %v \n", rootPath)
+	}
+	indexes := map[string]*Nodist{}
+	for _, local := range list {
 		// set version
-		version := file.Name()
+		version, ver := local.Folder, local.Version
+		global, latest := version == config.GetConfig(config.GLOBAL_VERSION), version == config.GetConfig(config.LATEST_VERSION)
 
-		// check node version
-		if util.VerifyNodeVer(version) {
-
-			// <root>/x.xx.xx/node.exe is exist
-			if util.IsDirExist(rootPath + version + util.DIVIDE + util.NODE) {
-				desc := ""
-				switch {
-				case version == config.GetConfig(config.GLOBAL_VERSION) && version == config.GetConfig(config.LATEST_VERSION):
-					desc = " -- global, latest"
-				case version == config.GetConfig(config.LATEST_VERSION):
-					desc = " -- latest"
-				case version == config.GetConfig(config.GLOBAL_VERSION):
-					desc = " -- global"
-				}
+		// set markers, e.g. global, latest, x86
+		markers := []string{}
+		if global {
+			markers = append(markers, util.GLOBAL)
+		}
+		if latest {
+			markers = append(markers, util.LATEST)
+		}
+		if _, _, _, suffix, _ := util.ParseNodeVer(version); suffix == "x86" || suffix == "x64" {
+			markers = append(markers, suffix)
+		}
 
-				ver, _, _, suffix, _ := util.ParseNodeVer(version)
-				if suffix == "x86" {
-					desc = " -- x86"
-				} else if suffix == "x64" {
-					desc = " -- x64"
-				}
+		// set advisory markers, e.g. eol, security, plain gnvm ls only usage cache
+		var w *manager.Warning
+		if isPrint {
+			if w = advise(version, long); w != nil && w.EOL {
+				markers = append(markers, "eol")
+			}
+			if w != nil && w.Security != "" {
+				markers = append(markers, "security")
+			}
+		}
+		desc := ""
+		if len(markers) > 0 {
+			desc = " -- " + strings.Join(markers, ", ")
+		}
 
-				// set true
-				existVersion = true
+		// set true
+		existVersion = true
 
-				// set lsArr
-				lsArr = append(lsArr, version)
+		// set lsArr
+		lsArr = append(lsArr, version)
+		l := Local{Version: ver, Arch: local.Arch, Global: global, Latest: latest, Path: local.Path}
+		if w != nil {
+			l.EOL, l.Security = w.EOL, w.Security
+			warns++
+		}
+		if long && isPrint {
+			localMeta(&l, version, indexes)
+		}
+		locals, descs = append(locals, l), append(descs, strings.TrimPrefix(desc, " -- "))
 
-				if isPrint {
-					if desc == "" {
-						P(DEFAULT, "v"+ver+desc, "\n")
-					} else {
-						P(DEFAULT, "%v", "v"+ver+desc, "\n")
-					}
+		if isPrint && util.IsText() && !long {
+			// channel version not prefix 'v', e.g. rc@22.0.0-rc.1
+			if !strings.Contains(ver, util.CHANNEL_SEP) {
+				ver = "v" + ver
+			}
+			if desc == "" {
+				P(DEFAULT, ver+desc, "\n")
+			} else {
+				P(DEFAULT, "%v", ver+desc, "\n")
+			}
+		}
+	}
 
-				}
+	// print json or tsv
+	if isPrint && !util.IsText() {
+		header := []string{"version", "arch", "global", "latest", "path"}
+		if long {
+			header = append(header, "size", "installed", "registry", "npm", "lts", "used")
+		}
+		rows := make([][]string, 0, len(locals))
+		for _, l := range locals {
+			row := []string{l.Version, l.Arch, strconv.FormatBool(l.Global), strconv.FormatBool(l.Latest), l.Path}
+			if long {
+				row = append(row, strconv.FormatInt(l.Size, 10), l.Installed, l.Registry, l.NPM, l.LTS, l.Used)
 			}
+			rows = append(rows, row)
 		}
+		if locals == nil {
+			locals = []Local{}
+		}
+		util.PrintDoc(locals, header, rows)
+		return lsArr, err
 	}
 
 	// version is exist
 	if !existVersion {
 		P(WARING, "don't have any available Node.js version, please check your input. See '%v'.\n", "gnvm help install")
+	} else if isPrint && long {
+		lsLong(locals, descs)
+	}
+	if isPrint && warns > 0 {
+		P(WARING, "%v Node.js versions are end-of-life or have a newer security release. See '%v'.\n", warns, "gnvm outdated")
 	}
 
 	return lsArr, err
 }
 
+/*
+Set long fields of Local, npm and lts from <registry>/index.json, when not found, it is ""
+
+Param:
+  - l:       *Local
+  - folder:  local Node.js version folder, e.g. x.xx.xx-x86 rc@22.0.0-rc.1
+  - indexes: index.json cache, see index
+*/
+func localMeta(l *Local, folder string, indexes map[string]*Nodist) {
+	meta := mgr.Meta(folder)
+	l.Size, l.Registry = meta.Size, meta.Registry
+	if !meta.Installed.IsZero() {
+		l.Installed = meta.Installed.Local().Format(time.RFC3339)
+	}
+	if !meta.Used.IsZero() {
+		l.Used = meta.Used.Local().Format(time.RFC3339)
+	}
+	if util.IsBuild(rootPath, folder) {
+		return
+	}
+	ver, io, _, _, err := util.ParseNodeVer(folder)
+	if err != nil {
+		return
+	}
+
+	// registry of install, when unknown, usage current registry
+	url, channel := meta.Registry, ""
+	if c, version, _, ok := util.ParseChannel(ver); ok {
+		channel, ver, io = c, version, false
+	}
+	if !strings.HasPrefix(url, "http") {
+		if url, err = mgr.Channel(channel, io); err != nil {
+			return
+		}
+	}
+	if nodist := index(indexes, url); nodist != nil {
+		if nd, ok := nodist.nl["v"+ver]; ok {
+			l.NPM, l.LTS = nd.NPM.Version, nd.LTS
+		}
+	}
+}
+
+/*
+Print local Node.js version list as table, and total disk usage
+
+Param:
+  - locals: Local collection, include long fields
+  - descs:  markers of each Local, e.g. "global, latest, x86"
+*/
+func lsLong(locals []Local, descs []string) {
+	date := func(s string) string {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t.Format("2006-01-02")
+		}
+		return ""
+	}
+	header := []string{"version", "arch", "size", "installed", "registry", "npm", "lts", "last used", ""}
+	rows, size := [][]string{}, int64(0)
+	for i, l := range locals {
+		ver := l.Version
+		if !strings.Contains(ver, util.CHANNEL_SEP) {
+			ver = "v" + ver
+		}
+		rows = append(rows, []string{ver, l.Arch, util.FormatSize(l.Size), date(l.Installed), l.Registry, l.NPM, l.LTS, date(l.Used), descs[i]})
+		size += l.Size
+	}
+	printTable(header, rows)
+	P(DEFAULT, "%v Node.js versions, total %v.\n", len(locals), util.FormatSize(size))
+}
+
+/*
+Print rows as table, empty cell is '[x]', except the last column
+
+Param:
+  - header: column labels
+  - rows:   cell values, the same length as header
+*/
+func printTable(header []string, rows [][]string) {
+
+	// column width
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = len(h) + 2
+		for _, row := range rows {
+			if row[i] == "" && i < len(header)-1 {
+				row[i] = "[x]"
+			}
+			if width := len(row[i]) + 2; width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	// print
+	title, total := "", 0
+	for i, h := range header {
+		title += leftpad(h, widths[i])
+		total += widths[i]
+	}
+	line := "+" + strings.Repeat("-", total) + "+"
+	fmt.Println(line)
+	fmt.Println("| " + leftpad(strings.TrimRight(title, " "), total-2) + " |")
+	fmt.Println(line)
+	for _, row := range rows {
+		value := ""
+		for i := range header {
+			value += leftpad(row[i], widths[i])
+		}
+		fmt.Println("  " + strings.TrimRight(value, " "))
+	}
+	fmt.Println(line)
+}
+
 /*
 Print remote Node.js version list
 
 Param:
-  - limit: print max line
-  - io:    when io == true, print iojs
+  - limit:   print max line
+  - io:      when io == true, print iojs
+  - channel: release channel, e.g. rc nightly, when channel == "", print release
 */
-func LsRemote(limit int, io bool) {
+func LsRemote(limit int, io bool, channel string) (err error) {
 	// set url
-	url := config.GetConfig(config.REGISTRY)
-	if io {
-		url = config.GetIOURL(url)
+	url, err := mgr.Channel(strings.ToLower(strings.TrimSpace(channel)), io)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help ls")
 	}
 	url += util.NODELIST
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm ls --remote' an error has occurred. please check your input %v. \nError: ", url)
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	// print
-	P(DEFAULT, "Read all Node.js version list from %v, please wait.\n", url)
+	if util.IsText() {
+		P(DEFAULT, "Read all Node.js version list from %v, please wait.\n", url)
+	}
 
 	// generate nodist
 	nodist, err, code := New(url, nil)
 	if err != nil {
 		if code == -1 {
-			P(ERROR, "'%v' get url %v error, Error: %v\n", "gnvm ls -r -d", url, err)
-		} else {
-			P(ERROR, "%v an error has occurred. please check your input. Error: %v\n", "gnvm ls -r -d", err)
+			return util.Fail(util.EXIT_NETWORK, ERROR, "'%v' get url %v error, Error: %v\n", "gnvm ls -r -d", url, err)
 		}
-		return
+		return util.Fail(util.EXIT_ERROR, ERROR, "%v an error has occurred. please check your input. Error: %v\n", "gnvm ls -r -d", err)
 	}
 
 	if limit != -1 {
+		// mark end-of-life rows, channel version not include in schedule
+		if a := loadAdvisory(true); a != nil && channel == "" {
+			nodist.schedule = a.Schedule
+		}
 		nodist.Detail(limit)
+	} else if !util.IsText() {
+		versions, rows := make([]string, 0, len(nodist.Sorts)), make([][]string, 0, len(nodist.Sorts))
+		for _, v := range nodist.Sorts {
+			versions = append(versions, v[1:])
+			rows = append(rows, []string{v[1:]})
+		}
+		util.PrintDoc(versions, []string{"version"}, rows)
 	} else {
 		for _, v := range nodist.Sorts {
 			fmt.Println(v)
 		}
 	}
+	return nil
 }
 
 /*
@@ -523,14 +806,14 @@ Show local / global Node.js version
 Param:
   - args:   include: latest global
 */
-func NodeVersion(args []string) {
+func NodeVersion(args []string) (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm node-version %v' an error has occurred. please check. \nError: ", strings.Join(args, " "))
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
@@ -557,7 +840,7 @@ func NodeVersion(args []string) {
 					P(WARING, "latest version is %v, please use '%v'. See '%v'.\n", util.UNKNOWN, "gnvm node-version latest -r", "gnvm help node-version")
 				}
 			case args[0] == "latest" && remote:
-				remoteVersion := util.GetLatVer(latURL)
+				remoteVersion := util.GetLatVer(latURL())
 				if remoteVersion == "" {
 					P(ERROR, "get remote %v Node.js %v error, please check your input. See '%v'.\n", config.GetConfig(config.REGISTRY), "latest version", "gnvm help config")
 					return
@@ -592,6 +875,21 @@ func NodeVersion(args []string) {
 		}
 	}
 
+	if !util.IsText() {
+		doc, header, row := make(map[string]string), []string{}, []string{}
+		if isGlobal {
+			doc[util.GLOBAL] = global
+			header, row = append(header, util.GLOBAL), append(row, global)
+		}
+		if isLatest {
+			remoteVersion := util.GetLatVer(latURL())
+			doc[util.LATEST], doc["remote"] = latest, remoteVersion
+			header, row = append(header, util.LATEST, "remote"), append(row, latest, remoteVersion)
+		}
+		util.PrintDoc(doc, header, [][]string{row})
+		return nil
+	}
+
 	if isGlobal {
 		if global == util.UNKNOWN {
 			P(WARING, "global Node.js version is %v.\n", util.UNKNOWN)
@@ -612,16 +910,15 @@ func NodeVersion(args []string) {
 		} else {
 			P(DEFAULT, "Node.js %v version is %v.\n", "latest", latest)
 		}
-		remoteVersion := util.GetLatVer(latURL)
+		remoteVersion := util.GetLatVer(latURL())
 		if remoteVersion == "" {
-			P(ERROR, "get remote %v Node.js %v error, please check your input. See '%v'.\n", config.GetConfig(config.REGISTRY), "latest version", "gnvm help config")
-			return
+			return util.Fail(util.EXIT_NETWORK, ERROR, "get remote %v Node.js %v error, please check your input. See '%v'.\n", config.GetConfig(config.REGISTRY), "latest version", "gnvm help config")
 		}
 		if latest == util.UNKNOWN {
 			P(NOTICE, "remote Node.js %v version is %v from %v.\n", "latest", remoteVersion, config.GetConfig(config.REGISTRY))
 			//config.SetConfig(config.LATEST_VERSION, remoteVersion)
 			//P(DEFAULT, "Set success, local Node.js %v version is %v.\n", util.LATEST, remoteVersion)
-			return
+			return nil
 		}
 		v1 := util.FormatNodeVer(latest)
 		v2 := util.FormatNodeVer(remoteVersion)
@@ -630,6 +927,7 @@ func NodeVersion(args []string) {
 			P(WARING, "remote Node.js latest version %v %v local Node.js latest version %v, suggest to upgrade, usage '%v'.\n", remoteVersion, cp, latest, "gnvm update latest")
 		}
 	}
+	return nil
 }
 
 /*
@@ -638,13 +936,13 @@ Print gnvm.exe version
 Param:
   - remote: when remote == true, print CHANGELOG
 */
-func Version(remote, detail bool) {
+func Version(remote, detail bool) (err error) {
 
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'%v' an error has occurred. please check. \nError: ", "gnvm version -r")
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_NETWORK, "%v", e)
 		}
 	}()
 
@@ -653,6 +951,11 @@ func Version(remote, detail bool) {
 		arch = "64 bit"
 	}
 
+	if !util.IsText() {
+		versionDoc(localVersion, arch, remote, detail)
+		return nil
+	}
+
 	cp := CP{Red, true, None, true, "Kenshin Wang"}
 	P(DEFAULT, "Current version %v %v.", localVersion, arch, "\n")
 	P(DEFAULT, "Copyright (C) 2014-2016 %v <kenshin@ksria.com>", cp, "\n")
@@ -660,12 +963,12 @@ func Version(remote, detail bool) {
 	P(DEFAULT, "See %v for more information.", cp, "\n")
 
 	if !remote {
-		return
+		return nil
 	}
 
-	code, res, err := curl.Get("http://ksria.com/gnvm/CHANGELOG.md")
+	code, res, e := util.Get("http://ksria.com/gnvm/CHANGELOG.md")
 	if code != 0 {
-		panic(err)
+		panic(e)
 	}
 	defer res.Body.Close()
 
@@ -705,4 +1008,40 @@ func Version(remote, detail bool) {
 	if err := curl.ReadLine(res.Body, versionFunc); err != nil && err != io.EOF {
 		panic(err)
 	}
+	return nil
+}
+
+/*
+Print gnvm.exe version by util.Format( json or tsv )
+*/
+func versionDoc(localVersion, arch string, remote, detail bool) {
+	doc := map[string]string{"version": localVersion, "arch": arch}
+	header, row := []string{"version", "arch"}, []string{localVersion, arch}
+	if remote {
+		code, res, err := util.Get("http://ksria.com/gnvm/CHANGELOG.md")
+		if code != 0 {
+			panic(err)
+		}
+		defer res.Body.Close()
+		changelog := ""
+		versionFunc := func(content string, line int) bool {
+			if content != "" && line == 1 {
+				if arr := strings.Fields(content); len(arr) == 2 {
+					doc["latest"], doc["date"] = arr[0][1:], arr[1]
+				}
+			}
+			if line > 2 && detail {
+				changelog += content
+			}
+			return false
+		}
+		if err := curl.ReadLine(res.Body, versionFunc); err != nil && err != io.EOF {
+			panic(err)
+		}
+		header, row = append(header, "latest", "date"), append(row, doc["latest"], doc["date"])
+		if detail {
+			doc["changelog"] = changelog
+		}
+	}
+	util.PrintDoc(doc, header, [][]string{row})
 }
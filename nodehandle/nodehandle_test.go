@@ -0,0 +1,554 @@
+package nodehandle
+
+import (
+	// go
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	// local
+	"gnvm/config"
+	"gnvm/console"
+	"gnvm/internal/fake"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Initialize config and nodehandle usage temp root and fake registry
+*/
+func setup(t *testing.T) (string, *fake.Registry) {
+	root, _ := fake.Root(t)
+	reg := fake.NewRegistry(t, nil)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+	t.Setenv("GNVM_SESSION_NODE_HOME", "")
+
+	path, source := util.GlobalNodePath, util.RootSource
+	t.Cleanup(func() { util.GlobalNodePath, util.RootSource = path, source })
+	if err := util.SetRoot(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if config.SetConfig(config.REGISTRY, reg.URL) == "" {
+		t.Fatal("set registry fail")
+	}
+	if config.SetConfig(config.SCHEDULE, reg.URL) == "" {
+		t.Fatal("set schedule fail")
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	return root, reg
+}
+
+func TestInstallAndUse(t *testing.T) {
+	root, _ := setup(t)
+
+	if err := InstallNode([]string{"18.16.0"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, "18.16.0", util.NODE) || !util.IsDirExist(root, util.NODE) {
+		t.Fatal("18.16.0 not installed or not global")
+	}
+	if global := config.GetConfig(config.GLOBAL_VERSION); global != "18.16.0" {
+		t.Fatalf("globalversion is %v", global)
+	}
+
+	if err := InstallNode([]string{"16.20.0", "16.20.0-x86"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Use("16.20.0-x86"); err != nil {
+		t.Fatal(err)
+	}
+	if ver, _ := util.GetNodeVer(root); ver != "16.20.0" {
+		t.Fatalf("node --version is %v", ver)
+	}
+	if arch, _ := util.Arch(root); arch != "x86" {
+		t.Fatalf("global arch is %v", arch)
+	}
+
+	if err := Use("20.1.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Use not installed version, err %v", err)
+	}
+	if err := InstallNode([]string{"abc"}, false); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("InstallNode(abc), err %v", err)
+	}
+}
+
+func TestInstallNotFound(t *testing.T) {
+	_, reg := setup(t)
+	reg.Set("/v18.16.0/win-x64/"+util.NODE, nil)
+
+	if err := InstallNode([]string{"18.16.0"}, false); util.ExitCode(err) != util.EXIT_NETWORK {
+		t.Fatalf("InstallNode 404, exit code %v, err %v", util.ExitCode(err), err)
+	}
+
+	// exit code of the failed task, not the first task
+	if err := InstallNode([]string{"20.1.0", "18.16.0"}, false); util.ExitCode(err) != util.EXIT_NETWORK {
+		t.Fatalf("InstallNode(20.1.0, 18.16.0) 404, exit code %v, err %v", util.ExitCode(err), err)
+	}
+}
+
+func TestInstallFiles(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("x64 only")
+	}
+	root, reg := setup(t)
+	reg.Set("/"+util.NODELIST, []byte(`[{"version":"v18.16.0","files":["win-x86-exe","win-x64-zip"]},{"version":"v16.20.0","files":["osx-x64-tar"]}]`))
+	reg.Set("/v18.16.0/node-v18.16.0-win-x64.zip", fake.Zip(t, "18.16.0", "x64"))
+
+	if err := InstallNode([]string{"18.16.0"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if ver, _ := util.GetNodeVer(filepath.Join(root, "18.16.0")); ver != "18.16.0" || util.IsDirExist(root, "18.16.0", "node-v18.16.0-win-x64.zip") {
+		t.Fatalf("InstallNode(18.16.0) from zip, version %v", ver)
+	}
+	if err := InstallNode([]string{"16.20.0"}, false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("InstallNode(16.20.0) without Windows build, err %v", err)
+	}
+
+	nodist, err, _ := New(reg.URL+util.NODELIST, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec := nodist.nl["v18.16.0"].Exec; exec != "x86 x64" {
+		t.Fatalf("v18.16.0 exec is %v", exec)
+	}
+	if exec := nodist.nl["v16.20.0"].Exec; exec != "[x]" {
+		t.Fatalf("v16.20.0 exec is %v", exec)
+	}
+}
+
+func TestInstallChannel(t *testing.T) {
+	root, _ := setup(t)
+	nightly := fake.NewRegistry(t, []fake.Release{{Version: "22.0.0-nightly20240101abcdef", NPM: "10.5.0"}})
+	if config.SetConfig(config.CHANNEL_PREFIX+util.CHANNEL_NIGHTLY, nightly.URL) == "" {
+		t.Fatal("set channel.nightly fail")
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InstallNode([]string{"nightly"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, "nightly@22.0.0-nightly20240101abcdef", util.NODE) {
+		t.Fatal("nightly@22.0.0-nightly20240101abcdef not installed")
+	}
+	if err := InstallNode([]string{"rc/22.0.0-rc.1"}, false); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("InstallNode(rc) not configured, err %v", err)
+	}
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Use("nightly@22.0.0-nightly20240101abcdef"); err != nil {
+		t.Fatal(err)
+	}
+	if arr, err := LS(false, false); err != nil || len(arr) != 1 || arr[0] != "nightly@22.0.0-nightly20240101abcdef" {
+		t.Fatalf("LS() = %v, %v", arr, err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	root, _ := setup(t)
+	dir := t.TempDir()
+	for path, body := range map[string][]byte{
+		"versions/node/v18.16.0/bin/node": fake.Node("18.16.0", "x64"),
+		"versions/node/v16.20.0/bin/node": fake.Node("16.20.0", "x64"),
+		"alias/default":                   []byte("v16.20.0"),
+	} {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, body, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Import("nvm", dir, "copy"); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, "18.16.0", util.NODE) || !util.IsDirExist(root, "16.20.0", util.NODE) {
+		t.Fatal("nvm versions not imported")
+	}
+	if global := config.GetConfig(config.GLOBAL_VERSION); global != "16.20.0" {
+		t.Fatalf("globalversion is %v, want alias default 16.20.0", global)
+	}
+	if err := Import("nvm", t.TempDir(), "copy"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Import empty folder, err %v", err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	root, _ := setup(t)
+	if err := InstallNode([]string{"16.20.0"}, true); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "gnvm.toml")
+	if err := os.WriteFile(path, []byte("versions = [\"18.16.0\", \"latest\"]\nglobal = \"18.16.0\"\n\n[aliases]\nlatest = \"latest\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Sync(path, true, true); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(root, "18.16.0") || !util.IsDirExist(root, "16.20.0") {
+		t.Fatal("Sync(dry-run) changed local versions")
+	}
+	for i := 0; i < 2; i++ {
+		if err := Sync(path, true, false); err != nil {
+			t.Fatal(err)
+		}
+		if !util.IsDirExist(root, "18.16.0", util.NODE) || !util.IsDirExist(root, "20.1.0", util.NODE) || util.IsDirExist(root, "16.20.0") {
+			t.Fatal("Sync() local versions not match manifest")
+		}
+		if global, latest := config.GetConfig(config.GLOBAL_VERSION), config.GetConfig(config.LATEST_VERSION); global != "18.16.0" || latest != "20.1.0" {
+			t.Fatalf("globalversion is %v, latestversion is %v", global, latest)
+		}
+	}
+	if err := Sync(filepath.Join(t.TempDir(), "gnvm.json"), false, false); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Sync not exist manifest, err %v", err)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	root, _ := setup(t)
+	fake.Install(t, root, "18.15.0", "18.15.0", "x64")
+	if err := Use("18.15.0"); err != nil {
+		t.Fatal(err)
+	}
+	config.SetConfig(config.GLOBAL_VERSION, "18.15.0")
+	config.SetConfig(config.LATEST_VERSION, "18.15.0")
+
+	if err := Outdated(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Upgrade("18.15.0", false); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, "18.16.0", util.NODE) || config.GetConfig(config.GLOBAL_VERSION) != "18.15.0" {
+		t.Fatal("Upgrade(18.15.0) not install 18.16.0 or migrate global")
+	}
+	if err := Upgrade("global", true); err != nil {
+		t.Fatal(err)
+	}
+	if global, latest := config.GetConfig(config.GLOBAL_VERSION), config.GetConfig(config.LATEST_VERSION); global != "18.16.0" || latest != "18.16.0" {
+		t.Fatalf("globalversion is %v, latestversion is %v", global, latest)
+	}
+	if ver, _ := util.GetNodeVer(root); ver != "18.16.0" {
+		t.Fatalf("global node.exe version is %v", ver)
+	}
+	if err := Upgrade("14.0.0", false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Upgrade(14.0.0), err %v", err)
+	}
+}
+
+func TestAdvisory(t *testing.T) {
+	root, reg := setup(t)
+	reg.Set("/"+util.SCHEDULE, []byte(`{"v18": {"end": "2099-04-30"}, "v16": {"end": "2023-09-11"}}`))
+	fake.Install(t, root, "16.19.0", "16.19.0", "x64")
+	fake.Install(t, root, "18.16.0", "18.16.0", "x64")
+
+	// plain gnvm ls only usage cache, not fetch
+	if _, err := LS(true, false); err != nil {
+		t.Fatal(err)
+	}
+	if w := advise("16.19.0", false); w != nil || util.IsDirExist(root, manager.ADVISORY) {
+		t.Fatalf("advise(16.19.0) without cache = %+v", w)
+	}
+
+	if w := advise("16.19.0", true); w == nil || !w.EOL || w.Security != "16.20.0" {
+		t.Fatalf("advise(16.19.0) = %+v", w)
+	}
+	if w := advise("18.16.0", true); w != nil {
+		t.Fatalf("advise(18.16.0) = %+v", w)
+	}
+	if err := Use("16.19.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LS(true, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := LsRemote(0, false, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// next process read cache offline
+	advisory, advised = nil, false
+	reg.Set("/"+util.SCHEDULE, nil)
+	if w := advise("16.19.0", false); w == nil || !w.EOL {
+		t.Fatalf("advise(16.19.0) from cache = %+v", w)
+	}
+
+	if config.SetConfig(config.SILENCE, "16.19.0") == "" {
+		t.Fatal("set silence fail")
+	}
+	if w := advise("16.19.0", true); w != nil {
+		t.Fatalf("advise(16.19.0) silence = %+v", w)
+	}
+}
+
+func TestMigrateGlobals(t *testing.T) {
+	root, _ := setup(t)
+	fake.Install(t, root, "18.16.0", "18.16.0", "x64")
+	if err := Use("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	config.SetConfig(config.GLOBAL_VERSION, "18.16.0")
+	for path, body := range map[string]string{
+		"node_modules/typescript/package.json": `{"name": "typescript", "version": "5.4.5"}`,
+		"node_modules/npm/bin/npm-cli.js":      "npm",
+	} {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := MigrateGlobals("global", "18.16.0", false); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("MigrateGlobals(global, 18.16.0), err %v", err)
+	}
+	if err := MigrateGlobals("global", "20.1.0", false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("MigrateGlobals(global, 20.1.0) not installed, err %v", err)
+	}
+	if err := InstallWithGlobals("20.1.0", "global", true, false); err != nil {
+		t.Fatal(err)
+	}
+	if global := config.GetConfig(config.GLOBAL_VERSION); global != "20.1.0" || !util.IsDirExist(root, "20.1.0", util.NODE) {
+		t.Fatalf("InstallWithGlobals(20.1.0) not install or not global, globalversion is %v", global)
+	}
+	exec := util.Runner.(*fake.Exec)
+	if call := exec.Calls[len(exec.Calls)-1]; !strings.HasSuffix(call, " install -g --prefix "+root+" typescript@5.4.5") {
+		t.Fatalf("InstallWithGlobals(20.1.0) run %q", call)
+	}
+	if err := MigrateGlobals("18.16.0", "20.1.0", true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoctorLang(t *testing.T) {
+	setup(t)
+	config.SetConfig(config.LATEST_VERSION, "20.1.0")
+	defer console.SetLang(console.LANG_EN)
+
+	if checks := checkLatest(); len(checks) != 1 || checks[0].Message != "20.1.0 folder is not exist node.exe" || checks[0].Fix != "use 'gnvm install 20.1.0'" {
+		t.Fatalf("checkLatest() en = %+v", checks)
+	}
+	if err := console.SetLang(console.LANG_ZH_CN); err != nil {
+		t.Fatal(err)
+	}
+	if checks := checkLatest(); len(checks) != 1 || checks[0].Message != "20.1.0 文件夹下不存在 node.exe" || checks[0].Fix != "使用 'gnvm install 20.1.0'" {
+		t.Fatalf("checkLatest() zh-CN = %+v", checks)
+	}
+}
+
+func TestExport(t *testing.T) {
+	root, _ := setup(t)
+	if err := InstallNode([]string{"18.16.0"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if source, err := util.ReadSource(root, "18.16.0"); err != nil || source.Registry != config.GetConfig(config.REGISTRY) {
+		t.Fatalf("ReadSource(18.16.0) = %+v, %v", source, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "gnvm-lock.json")
+	if err := Export(path, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Export(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "18.16.0", util.NODE), fake.Node("18.16.1", "x64"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Export(path, true); util.ExitCode(err) != util.EXIT_CHECKSUM {
+		t.Fatalf("Export(verify) changed node.exe, err %v", err)
+	}
+	if err := Export(filepath.Join(t.TempDir(), "gnvm-lock.json"), true); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Export(verify) not exist lockfile, err %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	root, _ := setup(t)
+	if err := InstallNode([]string{"18.16.0", "20.1.0"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify("all", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "20.1.0", util.NODE), []byte("v20"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify("", false); util.ExitCode(err) != util.EXIT_CHECKSUM {
+		t.Fatalf("Verify() corrupted, err %v", err)
+	}
+	if err := Verify("18.16.0", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify("20.1.0", true); err != nil {
+		t.Fatal(err)
+	}
+	if ver, _ := util.GetNodeVer(filepath.Join(root, "20.1.0")); ver != "20.1.0" {
+		t.Fatalf("20.1.0 node --version is %v after repair", ver)
+	}
+	if err := Verify("16.20.0", false); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Verify(16.20.0) not installed, err %v", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	root, _ := setup(t)
+	if err := InstallNode([]string{"20.1.0", "18.16.0", "16.20.0"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := Use("20.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	config.SetConfig(config.GLOBAL_VERSION, "20.1.0")
+
+	// gnvm.json of current folder protect 16.20.0
+	dir, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(dir) })
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("gnvm.json", []byte(`{"versions": ["16.20.0"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Prune(1, 0, "", "", true); err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsDirExist(root, "18.16.0") {
+		t.Fatal("Prune(dry-run) removed 18.16.0")
+	}
+	if err := Prune(1, 0, "", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(root, "18.16.0") || !util.IsDirExist(root, "20.1.0") || !util.IsDirExist(root, "16.20.0") {
+		t.Fatal("Prune() not remove 18.16.0 only")
+	}
+	if err := Prune(0, 0, "abc", "", false); util.ExitCode(err) != util.EXIT_USAGE {
+		t.Fatalf("Prune(--older-than abc), err %v", err)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	root, _ := setup(t)
+
+	if err := Update(true); err != nil {
+		t.Fatal(err)
+	}
+	if latest := config.GetConfig(config.LATEST_VERSION); latest != "20.1.0" {
+		t.Fatalf("latestversion is %v", latest)
+	}
+	if ver, _ := util.GetNodeVer(root); ver != "20.1.0" {
+		t.Fatalf("node --version is %v", ver)
+	}
+
+	// latest already installed
+	if err := Update(false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	root, _ := setup(t)
+	fake.Install(t, root, "18.16.0", "18.16.0", "x64")
+
+	if err := Uninstall("18.16.0"); err != nil {
+		t.Fatal(err)
+	}
+	if util.IsDirExist(root, "18.16.0") {
+		t.Fatal("18.16.0 folder exist after Uninstall")
+	}
+	if err := Uninstall("18.16.0"); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Uninstall not exist folder, err %v", err)
+	}
+	if err := Uninstall(util.UNKNOWN); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("Uninstall(unknown), err %v", err)
+	}
+}
+
+func TestLS(t *testing.T) {
+	root, _ := setup(t)
+	fake.Install(t, root, "18.16.0", "18.16.0", "x64")
+	fake.Install(t, root, "20.1.0-x86", "20.1.0", "x86")
+	fake.Install(t, root, "npm", "0.0.0", "x64")
+
+	arr, err := LS(false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arr) != 2 || arr[0] != "18.16.0" || arr[1] != "20.1.0-x86" {
+		t.Fatalf("LS() = %v", arr)
+	}
+
+	l := Local{Version: "18.16.0"}
+	localMeta(&l, "18.16.0", map[string]*Nodist{})
+	if l.Size <= 0 || l.Installed == "" || l.Used != "" || l.NPM != "9.5.1" || l.LTS != "Hydrogen" {
+		t.Fatalf("localMeta(18.16.0) = %+v", l)
+	}
+	if _, err := LS(true, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNPM(t *testing.T) {
+	root, _ := setup(t)
+	if _, err := localNPMVer(); err == nil {
+		t.Fatal("localNPMVer without npm, err is nil")
+	}
+	if err := UninstallNPM(); util.ExitCode(err) != util.EXIT_NOT_INSTALLED {
+		t.Fatalf("UninstallNPM without npm, err %v", err)
+	}
+
+	fake.Install(t, root, "", "18.16.0", "x64")
+	if ver := getNodeNpmVer(); ver != "9.5.1" {
+		t.Fatalf("getNodeNpmVer() = %v", ver)
+	}
+}
+
+func TestNodistColumns(t *testing.T) {
+	_, reg := setup(t)
+	defer func() { selected = nil }()
+
+	nodist, err, _ := New(reg.URL+util.NODELIST, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nd := nodist.nl["v18.16.0"]
+	if nd.OpenSSL != "3.0.8+quic" || nd.Modules != "108" || nd.LTS != "Hydrogen" || nd.Security || len(nd.Files) != 2 {
+		t.Fatalf("New() v18.16.0 = %+v", nd)
+	}
+	if r := nodist.Releases(3)[2]; r.Version != "16.20.0" || r.V8 != "9.4.146.26-node.26" || !r.Security {
+		t.Fatalf("Releases() v16.20.0 = %+v", r)
+	}
+
+	if err := SetColumns("Version, openssl,modules"); err != nil {
+		t.Fatal(err)
+	}
+	values := []string{}
+	for _, col := range selectedColumns() {
+		values = append(values, cell(col, nodist.nl["v16.20.0"]))
+	}
+	if got := strings.Join(values, " "); got != "16.20.0 1.1.1t+quic 93" {
+		t.Fatalf("columns = %v", got)
+	}
+	if err := SetColumns("version,abi"); err == nil {
+		t.Fatal("SetColumns(version,abi) err is nil")
+	}
+	if err := SetColumns("all"); err != nil || len(selectedColumns()) != len(columns) {
+		t.Fatalf("SetColumns(all) = %v", err)
+	}
+}
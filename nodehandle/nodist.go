@@ -5,7 +5,6 @@ import (
 	// lib
 	"io"
 
-	"github.com/Kenshin/curl"
 	"github.com/bitly/go-simplejson"
 
 	// go
@@ -13,8 +12,11 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	// local
+	"gnvm/config"
+	"gnvm/manager"
 	"gnvm/util"
 )
 
@@ -33,11 +35,51 @@ type (
 		Date string
 		Node
 		NPM
+		LTS      string
+		V8       string
+		UV       string
+		Zlib     string
+		OpenSSL  string
+		Modules  string
+		Security bool
+		Files    []string
+	}
+
+	/*
+	   Structured Node.js version, usage --json and --format=tsv
+	*/
+	Release struct {
+		Version  string   `json:"version"`
+		Date     string   `json:"date,omitempty"`
+		Arch     []string `json:"arch"`
+		NPM      string   `json:"npm,omitempty"`
+		LTS      string   `json:"lts,omitempty"`
+		V8       string   `json:"v8,omitempty"`
+		UV       string   `json:"uv,omitempty"`
+		Zlib     string   `json:"zlib,omitempty"`
+		OpenSSL  string   `json:"openssl,omitempty"`
+		Modules  string   `json:"modules,omitempty"`
+		Security bool     `json:"security"`
+		Files    []string `json:"files,omitempty"`
+		Global   bool     `json:"global"`
+		Latest   bool     `json:"latest"`
+		EOL      bool     `json:"eol"`
+	}
+
+	/*
+	   NodeDetail column, usage --columns
+	*/
+	column struct {
+		name  string
+		label string
+		width int
+		value func(nd NodeDetail) string
 	}
 
 	Nodist struct {
-		nl    map[string]NodeDetail
-		Sorts []string
+		nl       map[string]NodeDetail
+		Sorts    []string
+		schedule map[string]manager.Line
 	}
 )
 
@@ -67,7 +109,7 @@ Return:
   - -4: parse json error
 */
 func New(url string, filter *regexp.Regexp) (*Nodist, error, int) {
-	code, res, err := curl.Get(url)
+	code, res, err := util.Get(url)
 	if err != nil {
 		return nil, err, code
 	}
@@ -102,9 +144,29 @@ func New(url string, filter *regexp.Regexp) (*Nodist, error, int) {
 			if npm == "" {
 				npm = "[x]"
 			}
+			lts, _ := value["lts"].(string)
+			v8, _ := value["v8"].(string)
+			uv, _ := value["uv"].(string)
+			zlib, _ := value["zlib"].(string)
+			openssl, _ := value["openssl"].(string)
+			modules, _ := value["modules"].(string)
+			security, _ := value["security"].(bool)
+			var files []string
 			exe := formatExe(ver[1:])
+			if arr, ok := value["files"].([]interface{}); ok {
+				files = []string{}
+				for _, file := range arr {
+					if f, ok := file.(string); ok {
+						files = append(files, f)
+					}
+				}
+				exe = "[x]"
+				if archs := util.WinArchs(files); len(archs) > 0 {
+					exe = strings.Join(archs, " ")
+				}
+			}
 			nodist.Sorts = append(nodist.Sorts, ver)
-			nodist.nl[ver] = NodeDetail{idx, date, Node{ver, exe}, NPM{npm}}
+			nodist.nl[ver] = NodeDetail{idx, date, Node{ver, exe}, NPM{npm}, lts, v8, uv, zlib, openssl, modules, security, files}
 			idx++
 		}
 	}
@@ -138,6 +200,98 @@ func FindNodeDetailByVer(url, ver string) (*NodeDetail, error) {
 	return nil, nil
 }
 
+/*
+NodeDetail columns, include: no date version exec npm lts security v8 uv zlib openssl modules files
+*/
+var columns = []column{
+	{"no", "No.", 6, func(nd NodeDetail) string { return strconv.Itoa(nd.ID + 1) }},
+	{"date", "date", 13, func(nd NodeDetail) string { return nd.Date }},
+	{"version", "node ver", 12, func(nd NodeDetail) string { return nd.Node.Version[1:] }},
+	{"exec", "exec", 10, func(nd NodeDetail) string { return nd.Node.Exec }},
+	{"npm", "npm ver", 9, func(nd NodeDetail) string { return nd.NPM.Version }},
+	{"lts", "lts", 10, func(nd NodeDetail) string { return nd.LTS }},
+	{"security", "security", 10, func(nd NodeDetail) string { return strconv.FormatBool(nd.Security) }},
+	{"v8", "v8", 14, func(nd NodeDetail) string { return nd.V8 }},
+	{"uv", "uv", 9, func(nd NodeDetail) string { return nd.UV }},
+	{"zlib", "zlib", 9, func(nd NodeDetail) string { return nd.Zlib }},
+	{"openssl", "openssl", 14, func(nd NodeDetail) string { return nd.OpenSSL }},
+	{"modules", "modules", 9, func(nd NodeDetail) string { return nd.Modules }},
+	{"files", "files", 7, func(nd NodeDetail) string { return strings.Join(nd.Files, ",") }},
+}
+
+const COLUMNS_DEFAULT = "no,date,version,exec,npm"
+
+/*
+Selected columns, when nil, print default columns and default tsv header
+*/
+var selected []column
+
+/*
+Set NodeDetail columns, usage gnvm ls -r -d and gnvm search
+
+Param:
+  - names: comma-separated column names, e.g. version,openssl,modules, or all
+
+Return:
+  - error: not a valid column
+*/
+func SetColumns(names string) error {
+	selected = nil
+	if strings.TrimSpace(strings.ToLower(names)) == "all" {
+		selected = columns
+		return nil
+	}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, col := range columns {
+			if col.name == name {
+				selected, found = append(selected, col), true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%v not a valid column, include: %v and all", name, columnNames())
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("columns is empty, include: %v and all", columnNames())
+	}
+	return nil
+}
+
+/*
+Return all column names, e.g. no, date, version
+*/
+func columnNames() string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		names = append(names, col.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+/*
+Return selected columns, default COLUMNS_DEFAULT
+*/
+func selectedColumns() []column {
+	if selected != nil {
+		return selected
+	}
+	cols := []column{}
+	for _, name := range strings.Split(COLUMNS_DEFAULT, ",") {
+		for _, col := range columns {
+			if col.name == name {
+				cols = append(cols, col)
+			}
+		}
+	}
+	return cols
+}
+
 /*
 Print NodeDetail collection
 
@@ -145,34 +299,142 @@ Param:
   - limit: print lines, when limit == 0, print all nodedetail
 */
 func (this *Nodist) Detail(limit int) {
-	table := `+--------------------------------------------------+
-| No.   date         node ver    exec      npm ver |
-+--------------------------------------------------+`
+	if !util.IsText() {
+		this.Print(limit)
+		return
+	}
 	if limit == 0 || limit > len(this.Sorts) {
 		limit = len(this.Sorts)
 	}
-	for idx, v := range this.Sorts {
-		if idx == 0 {
-			fmt.Println(table)
+	if limit == 0 {
+		return
+	}
+
+	// column width
+	cols := selectedColumns()
+	widths, total := make([]int, len(cols)), 0
+	for i, col := range cols {
+		widths[i] = col.width
+		for _, v := range this.Sorts[:limit] {
+			if width := len(cell(col, this.nl[v])) + 1; width > widths[i] {
+				widths[i] = width
+			}
 		}
-		if idx >= limit {
-			break
+		total += widths[i]
+	}
+
+	// header
+	header := ""
+	for i, col := range cols {
+		header += leftpad(col.label, widths[i])
+	}
+	header = strings.TrimRight(header, " ")
+	if len(header)+2 > total {
+		total = len(header) + 2
+	}
+	line := "+" + strings.Repeat("-", total) + "+"
+	fmt.Println(line)
+	fmt.Println("| " + leftpad(header, total-2) + " |")
+	fmt.Println(line)
+
+	// rows
+	for _, v := range this.Sorts[:limit] {
+		row := ""
+		for i, col := range cols {
+			row += leftpad(cell(col, this.nl[v]), widths[i])
+		}
+		if this.eol(v) {
+			row += " -- eol"
 		}
+		fmt.Println("  " + row)
+	}
+	fmt.Println(line)
+}
+
+/*
+Return true when release line of version is past end-of-life, when schedule is nil, return false
+
+Param:
+  - ver: e.g. v16.20.0
+*/
+func (this *Nodist) eol(ver string) bool {
+	line, ok := this.schedule[util.NodeLine(ver)]
+	return ok && line.EOL(time.Now())
+}
+
+/*
+Return column text value, when empty, return '[x]'
+*/
+func cell(col column, nd NodeDetail) string {
+	if value := col.value(nd); value != "" {
+		return value
+	}
+	return "[x]"
+}
+
+/*
+Return Release collection
+
+Param:
+  - limit: max count, when limit <= 0, return all
+
+Return:
+  - []Release
+*/
+func (this *Nodist) Releases(limit int) []Release {
+	if limit <= 0 || limit > len(this.Sorts) {
+		limit = len(this.Sorts)
+	}
+	releases := make([]Release, 0, limit)
+	latest, global := config.GetConfig(config.LATEST_VERSION), config.GetConfig(config.GLOBAL_VERSION)
+	for _, v := range this.Sorts[:limit] {
 		value := this.nl[v]
-		id := leftpad(strconv.Itoa(value.ID+1), 6)
-		date := leftpad(value.Date, 13)
-		ver := leftpad(value.Node.Version[1:], 12)
-		exe := leftpad(value.Node.Exec, 10)
-		npm := leftpad(value.NPM.Version, 9)
-		fmt.Println("  " + id + date + ver + exe + npm)
-		if idx == limit-1 {
-			fmt.Println("+--------------------------------------------------+")
+		arch := []string{}
+		if value.Node.Exec != "[x]" {
+			arch = strings.Fields(value.Node.Exec)
+		}
+		npm := value.NPM.Version
+		if npm == "[x]" {
+			npm = ""
 		}
+		ver := v[1:]
+		releases = append(releases, Release{ver, value.Date, arch, npm, value.LTS, value.V8, value.UV, value.Zlib, value.OpenSSL, value.Modules, value.Security, value.Files, ver == global, ver == latest, this.eol(v)})
+	}
+	return releases
+}
+
+/*
+Print Release collection by util.Format( json or tsv )
+
+Param:
+  - limit: print lines, when limit == 0, print all nodedetail
+*/
+func (this *Nodist) Print(limit int) {
+	releases := this.Releases(limit)
+	if selected != nil && util.Format == util.FORMAT_TSV {
+		header, rows := []string{}, make([][]string, 0, len(releases))
+		for _, col := range selected {
+			header = append(header, col.name)
+		}
+		for _, v := range this.Sorts[:len(releases)] {
+			row := []string{}
+			for _, col := range selected {
+				row = append(row, col.value(this.nl[v]))
+			}
+			rows = append(rows, row)
+		}
+		util.PrintDoc(releases, header, rows)
+		return
+	}
+	rows := make([][]string, 0, len(releases))
+	for _, r := range releases {
+		rows = append(rows, []string{r.Version, r.Date, strings.Join(r.Arch, ","), r.NPM, r.LTS, strconv.FormatBool(r.Global), strconv.FormatBool(r.Latest)})
 	}
+	util.PrintDoc(releases, []string{"version", "date", "arch", "npm", "lts", "global", "latest"}, rows)
 }
 
 /*
-Format exe
+Format exe by version, usage when index.json not include files
 
 Param:
   - version: Node.js version
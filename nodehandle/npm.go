@@ -5,7 +5,6 @@ import (
 	// lib
 	"compress/gzip"
 
-	. "github.com/Kenshin/cprint"
 	"github.com/Kenshin/curl"
 	"github.com/bitly/go-simplejson"
 
@@ -16,12 +15,12 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	// local
 	"gnvm/config"
+	. "gnvm/console"
 	"gnvm/util"
 )
 
@@ -139,6 +138,7 @@ Return:
 func (this *NPMange) Download(url, name string) error {
 	curl.Options.Header = false
 	curl.Options.Footer = false
+	Debug("GET %v to %v\n", url, this.root)
 	if _, errs := curl.New(url, name, name, this.root); len(errs) > 0 {
 		return errs[0]
 	}
@@ -278,7 +278,7 @@ func (this *NPMange) Install() error {
 		files := [2]string{this.command1, this.command2}
 		for _, v := range files {
 			if err := util.Copy(this.npmbin, this.root, v); err != nil {
-				P(ERROR, "copy %v to %v faild, Error: %v \n", this.npmbin, this.root)
+				P(ERROR, "copy %v to %v faild, Error: %v \n", this.npmbin, this.root, err.Error())
 				return err
 			}
 		}
@@ -324,24 +324,28 @@ func (this *NPMange) CleanAll() error {
 
 /*
 Install NPM
+
+Param:
+  - version: include: latest global x.xx.xx
 */
-func InstallNPM(version string) {
+func InstallNPM(version string) (err error) {
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm npm %v' an error has occurred. please check. \nError: ", version)
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	version = strings.ToLower(version)
 	if !util.VerifyNodeVer(version) {
-		P(ERROR, "'%v' param only support [%v] [%v] or %v e.g. [%v], please check your input. See '%v'.\n", "gnvm npm", "latest", "global", "valid version", "3.8.1", "gnvm help npm")
-		return
+		err = &util.VersionError{Input: version, Err: util.ErrInvalidVersion}
+		P(ERROR, "%v, '%v' param only support [%v] [%v] or %v e.g. [%v]. See '%v'.\n", err.Error(), "gnvm npm", "latest", "global", "valid version", "3.8.1", "gnvm help npm")
+		return err
 	}
 
-	prompt, local, newver := "n", getLocalNPMVer(), version
+	local, newver := getLocalNPMVer(), version
 
 	if version == util.GLOBAL {
 		newver = getNodeNpmVer()
@@ -352,26 +356,26 @@ func InstallNPM(version string) {
 	cp := CP{Red, false, None, false, newver}
 	P(NOTICE, "local    npm version is %v\n", local)
 	P(NOTICE, "remote   npm version is %v\n", cp)
-	P(NOTICE, "download %v version [Y/n]? ", cp)
-	fmt.Scanf("%s\n", &prompt)
-	prompt = strings.ToLower(prompt)
-	if prompt == "y" {
-		downloadNpm(newver)
-	} else {
-		P(NOTICE, "operation has been cancelled.")
+	if ok, err := util.Confirm("download %v version [Y/n]? ", cp); err != nil {
+		return err
+	} else if ok {
+		return downloadNpm(newver)
 	}
+	return util.Fail(util.EXIT_CANCELLED, NOTICE, "operation has been cancelled.")
 }
 
 /*
 Uninstall NPM
 */
-func UninstallNPM() {
+func UninstallNPM() error {
 	if getLocalNPMVer() == util.UNKNOWN {
-		return
+		return util.Errorf(util.EXIT_NOT_INSTALLED, "current path %v not exist npm", rootPath)
 	}
-	if err := npm.New().CleanAll(); err == nil {
-		P(DEFAULT, "Npm uninstall %v.\n", "success")
+	if err := npm.New().CleanAll(); err != nil {
+		return util.Fail(util.EXIT_ERROR, ERROR, "Npm uninstall fail, Error: %v.\n", err.Error())
 	}
+	P(DEFAULT, "Npm uninstall %v.\n", "success")
+	return nil
 }
 
 /*
@@ -386,10 +390,7 @@ func getNodeNpmVer() string {
 		panic(errors.New("not exist global node.exe. please usage 'gnvm install latest -g' frist."))
 	}
 
-	url := config.GetConfig(config.REGISTRY)
-	if level := util.GetNodeVerLev(util.FormatNodeVer(ver)); level == 3 {
-		url = config.GetIOURL(url)
-	}
+	url, _ := mgr.Channel("", util.GetNodeVerLev(util.FormatNodeVer(ver)) == 3)
 	url += util.NODELIST
 
 	nd, err := FindNodeDetailByVer(url, ver)
@@ -406,7 +407,7 @@ Return:
   - string: latest npm version
 */
 func getLatNPMVer() string {
-	_, res, err := curl.Get(LATNPMURL)
+	_, res, err := util.Get(LATNPMURL)
 	if err != nil {
 		panic(err)
 	}
@@ -433,12 +434,20 @@ Return:
   - version     : current npm version
 */
 func getLocalNPMVer() string {
-	out, err := exec.Command(rootPath+util.NPM, "-v").Output()
+	ver, err := localNPMVer()
 	if err != nil {
 		P(WARING, "current path %v not exist npm.\n", rootPath)
 		return util.UNKNOWN
 	}
-	return strings.TrimSpace(string(out[:]))
+	return ver
+}
+
+func localNPMVer() (string, error) {
+	out, err := util.Runner.Output(rootPath+util.NPM, "-v")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out[:])), nil
 }
 
 /*
@@ -446,8 +455,11 @@ Download / unzip / install npm
 
 Param:
   - ver: npm version
+
+Return:
+  - error: install fail
 */
-func downloadNpm(ver string) {
+func downloadNpm(ver string) error {
 	version := "v" + ver + ZIP
 	url := NPMTAOBAO + version
 	if config.GetConfig(config.REGISTRY) == util.ORIGIN_TAOBAO {
@@ -469,7 +481,7 @@ func downloadNpm(ver string) {
 
 	// download
 	if err := npm.Download(url, version); err != nil {
-		panic(err.Error())
+		return util.Errorf(util.EXIT_NETWORK, "%v", err.Error())
 	}
 
 	// create node_modules
@@ -483,21 +495,21 @@ func downloadNpm(ver string) {
 		P(DEFAULT, "Start untgz and install %v tgz file, please wait.\n", version)
 		//untgz
 		if _, err := npm.Untgz(); err != nil {
-			msg := fmt.Sprintf("untgz %v an error has occurred. \nError: ", npm.tgzname, err.Error())
+			msg := fmt.Sprintf("untgz %v an error has occurred. \nError: %v", npm.tgzname, err.Error())
 			panic(errors.New(msg))
 		}
 	} else {
 		P(DEFAULT, "Start unzip and install %v zip file, please wait.\n", version)
 		// unzip
 		if _, err := npm.Unzip(); err != nil {
-			msg := fmt.Sprintf("unzip %v an error has occurred. \nError: ", npm.zipname, err.Error())
+			msg := fmt.Sprintf("unzip %v an error has occurred. \nError: %v", npm.zipname, err.Error())
 			panic(errors.New(msg))
 		}
 	}
 
 	// install
 	if err := npm.Install(); err != nil {
-		return
+		return util.Errorf(util.EXIT_ERROR, "%v", err.Error())
 	}
 
 	// remove download zip or tgz file
@@ -508,4 +520,5 @@ func downloadNpm(ver string) {
 	}
 
 	P(DEFAULT, "Set success, current npm version is %v.\n", ver)
+	return nil
 }
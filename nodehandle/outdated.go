@@ -0,0 +1,168 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+	"strconv"
+	"strings"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Print local Node.js versions next to the newest remote patch in the same line, flag security releases and end-of-life lines
+
+Return:
+  - err: *util.ExitError
+*/
+func Outdated() (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			Error(ERROR, "'gnvm outdated' an error has occurred. please check. \nError: ", e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	list, err := mgr.Outdated()
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help outdated")
+	}
+
+	header, rows, count := []string{"version", "latest", "line", "eol", "security", "outdated"}, [][]string{}, 0
+	for _, o := range list {
+		rows = append(rows, []string{o.Folder, o.Latest, o.Line, o.End, strconv.FormatBool(o.Security), strconv.FormatBool(o.Target != "")})
+	}
+	if !util.IsText() {
+		util.PrintDoc(list, header, rows)
+		return nil
+	}
+	if len(list) == 0 {
+		P(WARING, "don't have any available Node.js version, please check your input. See '%v'.\n", "gnvm help install")
+		return nil
+	}
+
+	P(NOTICE, "compare local Node.js versions with %v.\n", mgr.Registry())
+	for i, o := range list {
+		rows[i] = []string{"v" + o.Folder, "v" + o.Latest, o.Line, o.End, strings.Join(outdatedMarkers(o), ", ")}
+		if o.Target != "" {
+			count++
+		}
+	}
+	printTable([]string{"version", "latest", "line", "eol", ""}, rows)
+	if count == 0 {
+		P(DEFAULT, "All Node.js versions are up to date.\n")
+		return nil
+	}
+	P(DEFAULT, "%v of %v Node.js versions are outdated, use '%v' to install the newest patches.\n", count, len(list), "gnvm upgrade all")
+	return nil
+}
+
+/*
+Return markers of Outdated, include: outdated security eol
+*/
+func outdatedMarkers(o manager.Outdated) []string {
+	markers := []string{}
+	if o.Target != "" {
+		markers = append(markers, "outdated")
+	}
+	if o.Security {
+		markers = append(markers, "security")
+	}
+	if o.EOL {
+		markers = append(markers, "eol")
+	}
+	return markers
+}
+
+/*
+Install the newest remote patch in the same line of local Node.js versions
+
+Param:
+  - version: include: all latest global x.xx.xx x.xx.xx-x86
+  - migrate: when true, global and latest move to the new patch
+
+Return:
+  - err: *util.ExitError
+*/
+func Upgrade(version string, migrate bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm upgrade %v' an error has occurred. please check. \nError: ", version)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	list, err := mgr.Outdated()
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help upgrade")
+	}
+
+	folder := ""
+	switch version = strings.ToLower(strings.TrimSpace(version)); version {
+	case "all":
+	case util.LATEST:
+		folder = config.GetConfig(config.LATEST_VERSION)
+	case util.GLOBAL:
+		folder = config.GetConfig(config.GLOBAL_VERSION)
+	default:
+		folder = util.ChannelFolder(version)
+	}
+
+	targets := []manager.Outdated{}
+	for _, o := range list {
+		if folder == "" || o.Folder == folder {
+			targets = append(targets, o)
+		}
+	}
+	if folder != "" && len(targets) == 0 {
+		return util.Fail(util.EXIT_NOT_INSTALLED, ERROR, "%v is not installed or not a release version. See '%v'.\n", folder, "gnvm outdated")
+	}
+
+	count := 0
+	for _, o := range targets {
+		if o.Target == "" {
+			if folder != "" {
+				P(DEFAULT, "%v is the newest version of line %v, don't need to upgrade.\n", o.Folder, o.Line)
+			}
+			continue
+		}
+		count++
+		P(DEFAULT, "Upgrade %v to %v.\n", o.Folder, o.Target)
+		if e := InstallNode([]string{o.Target}, false); e != nil {
+			err = e
+			continue
+		}
+
+		// migrate global and latest
+		for _, alias := range []string{config.GLOBAL_VERSION, config.LATEST_VERSION} {
+			if config.GetConfig(alias) != o.Folder {
+				continue
+			}
+			if !migrate {
+				P(NOTICE, "%v is %v, use '%v' to migrate.\n", alias, o.Folder, "gnvm upgrade "+o.Folder+" --migrate")
+				continue
+			}
+			if alias == config.GLOBAL_VERSION {
+				if e := Use(o.Target); e != nil {
+					err = e
+					continue
+				}
+			}
+			config.SetConfig(alias, o.Target)
+			P(DEFAULT, "Set success, %v new value is %v\n", alias, o.Target)
+		}
+	}
+	if count == 0 && folder == "" {
+		P(DEFAULT, "All Node.js versions are up to date.\n")
+	}
+	return err
+}
@@ -0,0 +1,97 @@
+package nodehandle
+
+import (
+	// go
+	"os"
+	"time"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Remove old local Node.js versions by keep policies, global, latest and manifest versions are always protected
+
+Param:
+  - keep:        keep the newest N versions
+  - perMajor:    keep the newest N versions of each major
+  - olderThan:   only remove versions installed before, e.g. 180d 2024-01-01, see util.ParseSince
+  - unusedSince: only remove versions not used by gnvm use since, e.g. 90d
+  - dryRun:      when true, only print versions and free space
+
+Return:
+  - err: *util.ExitError
+*/
+func Prune(keep, perMajor int, olderThan, unusedSince string, dryRun bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			Error(ERROR, "'gnvm prune' an error has occurred. please check. \nError: ", e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	policy, now := manager.PrunePolicy{Keep: keep, KeepLatestPerMajor: perMajor}, time.Now()
+	for _, v := range []struct {
+		flag, value string
+		t           *time.Time
+	}{{"--older-than", olderThan, &policy.OlderThan}, {"--unused-since", unusedSince, &policy.UnusedSince}} {
+		if v.value == "" {
+			continue
+		}
+		t, e := util.ParseSince(v.value, now)
+		if e != nil {
+			return util.Fail(util.EXIT_USAGE, ERROR, "%v value %v, Error: %v. See '%v'.\n", v.flag, v.value, e.Error(), "gnvm help prune")
+		}
+		*v.t = t
+	}
+
+	// protect global, latest and manifest versions of current folder
+	policy.Protect = []string{config.GetConfig(config.GLOBAL_VERSION), config.GetConfig(config.LATEST_VERSION)}
+	dir, _ := os.Getwd()
+	if path := manager.FindManifest(dir); path != "" {
+		mf, e := manager.ReadManifest(path)
+		if e != nil {
+			return util.Fail(util.ExitCode(e), ERROR, "%v. See '%v'.\n", e.Error(), "gnvm help sync")
+		}
+		plan, e := mgr.Plan(mf, false)
+		if e != nil {
+			return util.Fail(util.ExitCode(e), ERROR, "%v. See '%v'.\n", e.Error(), "gnvm help sync")
+		}
+		for _, folder := range plan.Folders {
+			policy.Protect = append(policy.Protect, folder)
+		}
+		P(NOTICE, "protect Node.js versions of %v.\n", path)
+	}
+
+	pruned, err := mgr.Prune(policy)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help prune")
+	}
+	if len(pruned) == 0 {
+		P(DEFAULT, "Nothing to prune.\n")
+		return nil
+	}
+
+	var size int64
+	for _, v := range pruned {
+		size += v.Size
+		P(DEFAULT, "  - %v %v, installed %v, last used %v\n", v.Folder, util.FormatSize(v.Size), v.Installed.Local().Format("2006-01-02"), v.Used.Local().Format("2006-01-02"))
+	}
+	if dryRun {
+		P(NOTICE, "%v Node.js versions would be removed, free %v.\n", len(pruned), util.FormatSize(size))
+		return nil
+	}
+	for _, v := range pruned {
+		if e := Uninstall(v.Folder); e != nil {
+			err = e
+			size -= v.Size
+		}
+	}
+	P(NOTICE, "%v free.\n", util.FormatSize(size))
+	return err
+}
@@ -3,28 +3,25 @@ package nodehandle
 import (
 
 	// lib
-	. "github.com/Kenshin/cprint"
 	"github.com/Kenshin/regedit"
 
 	// go
-	"fmt"
 	"os"
-	"strings"
 
 	// local
 	"gnvm/config"
+	. "gnvm/console"
 	"gnvm/util"
 )
 
 const NODE_HOME, PATH = "NODE_HOME", "Path"
 
-var nodehome, noderoot string
+var nodehome string
 
-func init() {
-	noderoot = config.GetConfig(config.NODEROOT)
+func initReg() {
 	nodehome = os.Getenv(NODE_HOME)
-	if nodehome == "" && config.GetConfig(config.GLOBAL_VERSION) == util.UNKNOWN {
-		P(NOTICE, "not found environment variable '%v', please use '%v'. See '%v'.\n", NODE_HOME, "gnvm reg noderoot", "gnvm help reg")
+	if nodehome == "" && config.GetConfig(config.GLOBAL_VERSION) == util.UNKNOWN && util.IsText() {
+		Verbose("not found environment variable '%v', please use '%v'. See '%v'.\n", NODE_HOME, "gnvm reg noderoot", "gnvm help reg")
 	}
 }
 
@@ -35,41 +32,43 @@ func init() {
  	- s: olny support 'noderoot'
 
 */
-func Reg(s string) {
-	prompt := "n"
+func Reg(s string) error {
+	noderoot := config.GetConfig(config.NODEROOT)
 
 	P(WARING, "this command is %v, need %v permission, please note!\n", "experimental function", "Administrator")
 	if nodehome != "" {
 		P(NOTICE, "current environment variable %v is %v\n", NODE_HOME, nodehome)
 	}
 	P(NOTICE, "current config %v is %v\n", "noderoot", noderoot)
-	P(NOTICE, "set environment variable %v is %v [Y/n]? ", NODE_HOME, noderoot)
 
-	fmt.Scanf("%s\n", &prompt)
-	prompt = strings.ToLower(prompt)
-
-	if prompt == "y" {
+	if ok, err := util.Confirm("set environment variable %v is %v [Y/n]? ", NODE_HOME, noderoot); err != nil {
+		return err
+	} else if ok {
 		if add(NODE_HOME, noderoot) == nil {
 			if arr, err := query(PATH); err == nil {
-				prompt = "n"
-				P(NOTICE, "add environment variable %v to %v [Y/n]? ", NODE_HOME, PATH)
-				fmt.Scanf("%s\n", &prompt)
-
-				prompt = strings.ToLower(prompt)
-				if prompt == "y" {
+				if ok, err := util.Confirm("add environment variable %v to %v [Y/n]? ", NODE_HOME, PATH); err != nil {
+					return err
+				} else if ok {
 					regval := ""
 					if len(arr) > 0 {
 						regval = ";" + arr[0].Value
 					}
-					add(PATH, noderoot+regval)
+					if err := add(PATH, noderoot+regval); err != nil {
+						return util.Errorf(util.EXIT_ERROR, "%v", err)
+					}
 				} else {
-					P(NOTICE, "operation has been cancelled.")
+					return util.Fail(util.EXIT_CANCELLED, NOTICE, "operation has been cancelled.")
 				}
+			} else {
+				return util.Errorf(util.EXIT_ERROR, "%v", err)
 			}
+		} else {
+			return util.Errorf(util.EXIT_ERROR, "add environment variable %v fail", NODE_HOME)
 		}
 	} else {
-		P(NOTICE, "operation has been cancelled.")
+		return util.Fail(util.EXIT_CANCELLED, NOTICE, "operation has been cancelled.")
 	}
+	return nil
 }
 
 func add(key, value string) (err error) {
@@ -1,14 +1,12 @@
 package nodehandle
 
 import (
-	// lib
-	. "github.com/Kenshin/cprint"
-
 	// go
 	"fmt"
 	"os"
 
 	// local
+	. "gnvm/console"
 	"gnvm/util"
 )
 
@@ -144,7 +142,7 @@ goto exit
 exit /b 0
 
 `
-var GNS_HOME = util.GlobalNodePath + util.DIVIDE + "gns.cmd"
+var GNS_HOME string
 
 /*
  Regedit
@@ -153,51 +151,51 @@ var GNS_HOME = util.GlobalNodePath + util.DIVIDE + "gns.cmd"
  	- action: olny support 'start' and 'close'
 
 */
-func Run(action string) {
+func Run(action string) (err error) {
 
 	// try catch
 	defer func() {
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			msg := fmt.Sprintf("'gnvm session' an error has occurred. please check. \nError: ")
-			Error(ERROR, msg, err)
-			os.Exit(0)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
 		}
 	}()
 
 	if _, err := util.GetNodeVer(util.GlobalNodePath); err != nil {
-		P(ERROR, "not found %v node.exe, not use %v. please use '%v'. See '%v'.\n", "global", "gnvm session "+action, "gnvm install x.xx.xx -g", "gnvm help install")
-		return
+		return util.Fail(util.EXIT_NOT_INSTALLED, ERROR, "not found %v node.exe, not use %v. please use '%v'. See '%v'.\n", "global", "gnvm session "+action, "gnvm install x.xx.xx -g", "gnvm help install")
 	}
 
 	if action == "start" {
-		start()
-	} else {
-		close()
+		return start()
 	}
+	return close()
 }
 
-func start() {
+func start() error {
 	file, err := os.Create(GNS_HOME)
 	defer file.Close()
 	if err != nil {
 		if err := os.Remove(GNS_HOME); err != nil {
 			msg := fmt.Sprintf("'gnvm session start' an error has occurred. please check. \nError: ")
 			Error(ERROR, msg, err)
-			return
+			return util.Errorf(util.EXIT_SESSION, "%v", err)
 		}
 	}
-	if _, err := file.WriteString(batFileContent); err == nil {
-		P(NOTICE, "sesson environment %v, path is %v.\n", "start success", GNS_HOME)
-		P(NOTICE, "please use '%v'. See '%v' or '%v'.\n", "gns run x.xx.xx", "gnvm help session", "gns help")
+	if _, err := file.WriteString(batFileContent); err != nil {
+		return util.Errorf(util.EXIT_SESSION, "%v", err)
 	}
+	P(NOTICE, "sesson environment %v, path is %v.\n", "start success", GNS_HOME)
+	P(NOTICE, "please use '%v'. See '%v' or '%v'.\n", "gns run x.xx.xx", "gnvm help session", "gns help")
+	return nil
 }
 
-func close() {
+func close() error {
 	if err := os.Remove(GNS_HOME); err != nil {
 		msg := fmt.Sprintf("'gnvm session close' an error has occurred. please check. \nError: ")
 		Error(ERROR, msg, err)
-		return
-	} else {
-		P(NOTICE, "sesson environment %v.\n", "close success")
+		return util.Errorf(util.EXIT_SESSION, "%v", err)
 	}
+	P(NOTICE, "sesson environment %v.\n", "close success")
+	return nil
 }
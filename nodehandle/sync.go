@@ -0,0 +1,134 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+	"os"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Sync local Node.js versions, global, latest and npm by manifest, print diff first
+
+  - '+': install Node.js version
+  - '-': remove Node.js version, only when prune
+  - '=': Node.js version already installed
+  - '~': change global, latest or npm
+
+Param:
+  - path:   manifest path, when path == "", usage gnvm.json or gnvm.toml of current folder
+  - prune:  when true, remove local Node.js versions which not in manifest
+  - dryRun: when true, only print diff
+
+Return:
+  - err: *util.ExitError
+*/
+func Sync(path string, prune, dryRun bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm sync %v' an error has occurred. please check. \nError: ", path)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	if path == "" {
+		dir, _ := os.Getwd()
+		if path = manager.FindManifest(dir); path == "" {
+			return util.Fail(util.EXIT_USAGE, ERROR, "not found %v or %v in %v. See '%v'.\n", manager.MANIFEST_JSON, manager.MANIFEST_TOML, dir, "gnvm help sync")
+		}
+	}
+	mf, err := manager.ReadManifest(path)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help sync")
+	}
+	plan, err := mgr.Plan(mf, prune || mf.Prune)
+	if err != nil {
+		return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help sync")
+	}
+
+	// diff
+	global, latest, npm := plan.Folders[mf.Global], plan.Folders[mf.Aliases[util.LATEST]], mf.NPM
+	localNPM, _ := localNPMVer()
+	if global == config.GetConfig(config.GLOBAL_VERSION) {
+		global = ""
+	}
+	if latest == config.GetConfig(config.LATEST_VERSION) {
+		latest = ""
+	}
+	if npm == localNPM {
+		npm = ""
+	}
+	P(NOTICE, "sync %v to %v.\n", path, rootPath)
+	for _, v := range plan.Keep {
+		P(DEFAULT, "  = %v\n", v)
+	}
+	for _, v := range plan.Install {
+		P(DEFAULT, "  + %v\n", v)
+	}
+	for _, v := range plan.Remove {
+		P(DEFAULT, "  - %v\n", v)
+	}
+	if global != "" {
+		P(DEFAULT, "  ~ %v %v -> %v\n", config.GLOBAL_VERSION, config.GetConfig(config.GLOBAL_VERSION), global)
+	}
+	if latest != "" {
+		P(DEFAULT, "  ~ %v %v -> %v\n", config.LATEST_VERSION, config.GetConfig(config.LATEST_VERSION), latest)
+	}
+	if npm != "" {
+		P(DEFAULT, "  ~ %v %v -> %v\n", "npm", localNPM, npm)
+	}
+	if len(plan.Install) == 0 && len(plan.Remove) == 0 && global == "" && latest == "" && npm == "" {
+		P(DEFAULT, "Already in sync with %v.\n", path)
+		return nil
+	}
+	if dryRun {
+		return nil
+	}
+
+	// install in parallel
+	errs := []error{}
+	for i, e := range installAll(plan.Install) {
+		if e != nil {
+			errs = append(errs, e)
+			P(ERROR, "install %v fail, Error: %v.\n", plan.Install[i], e.Error())
+			continue
+		}
+		P(DEFAULT, "Node.js version %v install success.\n", plan.Install[i])
+	}
+	if len(errs) > 0 {
+		return util.Fail(util.ExitCode(errs[0]), ERROR, "%v Node.js versions install fail. See '%v'.\n", len(errs), "gnvm help sync")
+	}
+
+	if global != "" {
+		if e := Use(global); e != nil {
+			return e
+		}
+		config.SetConfig(config.GLOBAL_VERSION, global)
+	}
+	if latest != "" {
+		config.SetConfig(config.LATEST_VERSION, latest)
+		P(DEFAULT, "Set success, %v new value is %v\n", config.LATEST_VERSION, latest)
+	}
+	if npm != "" {
+		if _, e := mgr.InstallNPM(npm); e != nil {
+			return util.Fail(util.ExitCode(e), ERROR, "install npm %v fail, Error: %v.\n", npm, e.Error())
+		}
+		P(DEFAULT, "npm version %v install success.\n", npm)
+	}
+
+	// remove after global changed, global folder maybe in plan.Remove
+	for _, v := range plan.Remove {
+		if e := Uninstall(v); e != nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package nodehandle
+
+import (
+	// go
+	"fmt"
+	"strings"
+
+	// local
+	"gnvm/config"
+	. "gnvm/console"
+	"gnvm/manager"
+	"gnvm/util"
+)
+
+/*
+Verify installed Node.js versions, re-hash node.exe and check node --version, when repair, redownload corrupted versions
+
+Param:
+  - version: include: all latest global x.xx.xx x.xx.xx-x86 <channel>/x.xx.xx-<tag>, when version == "" or all, verify all versions and <root>/node.exe
+  - repair:  when true, redownload corrupted versions and copy global node.exe again
+
+Return:
+  - err: *util.ExitError, util.EXIT_CHECKSUM when found corrupted versions
+*/
+func Verify(version string, repair bool) (err error) {
+
+	// try catch
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("'gnvm verify %v' an error has occurred. please check. \nError: ", version)
+			Error(ERROR, msg, e)
+			err = util.Errorf(util.EXIT_ERROR, "%v", e)
+		}
+	}()
+
+	folders, global := []string{}, config.GetConfig(config.GLOBAL_VERSION)
+	switch version = strings.ToLower(strings.TrimSpace(version)); version {
+	case "", "all":
+		list, err := mgr.List()
+		if err != nil {
+			return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm help verify")
+		}
+		for _, local := range list {
+			folders = append(folders, local.Folder)
+		}
+	case util.LATEST:
+		folders = append(folders, config.GetConfig(config.LATEST_VERSION))
+	case util.GLOBAL:
+		folders = append(folders, global)
+	default:
+		folders = append(folders, util.ChannelFolder(version))
+	}
+	checkGlobal := version == "" || version == "all" || version == util.GLOBAL
+
+	broken := []*manager.Check{}
+	for _, folder := range folders {
+		check, err := mgr.Verify(folder)
+		if err != nil {
+			return util.Fail(util.ExitCode(err), ERROR, "%v. See '%v'.\n", err.Error(), "gnvm ls")
+		}
+		printVerify(check)
+		if !check.OK() {
+			broken = append(broken, check)
+		}
+	}
+	if checkGlobal && util.IsDirExist(rootPath, global, util.NODE) {
+		if check, err := mgr.VerifyGlobal(global); err == nil {
+			printVerify(check)
+			if !check.OK() {
+				broken = append(broken, check)
+			}
+		}
+	}
+
+	if len(broken) > 0 && repair {
+		failed := []*manager.Check{}
+		for _, check := range broken {
+			folder, e := check.Folder, error(nil)
+			if folder == util.GLOBAL {
+				if e = mgr.RepairGlobal(global); e == nil {
+					check, e = mgr.VerifyGlobal(global)
+				}
+			} else {
+				P(DEFAULT, "Start repair Node.js version %v, please wait.\n", folder)
+				if e = mgr.Repair(folder); e == nil {
+					check, e = mgr.Verify(folder)
+				}
+			}
+			if e != nil {
+				P(ERROR, "repair %v fail, Error: %v.\n", folder, e.Error())
+				failed = append(failed, &manager.Check{Folder: folder})
+				continue
+			}
+			if !check.OK() {
+				printVerify(check)
+				failed = append(failed, check)
+				continue
+			}
+			P(DEFAULT, "Repair %v success.\n", folder)
+		}
+		broken = failed
+	}
+
+	if len(broken) > 0 {
+		names := []string{}
+		for _, check := range broken {
+			names = append(names, check.Folder)
+		}
+		if repair {
+			return util.Fail(util.EXIT_CHECKSUM, ERROR, "found corrupted Node.js versions [%v]. See '%v'.\n", strings.Join(names, ", "), "gnvm help verify")
+		}
+		return util.Fail(util.EXIT_CHECKSUM, ERROR, "found corrupted Node.js versions [%v]. See '%v'.\n", strings.Join(names, ", "), "gnvm verify --repair")
+	}
+	P(DEFAULT, "Verify success, %v Node.js versions are ok.\n", len(folders))
+	return nil
+}
+
+func printVerify(check *manager.Check) {
+	switch check.Status {
+	case manager.CHECK_OK:
+		P(DEFAULT, "%v ok, sha256 %v from %v.\n", check.Folder, check.SHA256, check.From)
+	case manager.CHECK_UNVERIFIED:
+		P(WARING, "%v node --version is %v, but not found any checksum.\n", check.Folder, check.Version)
+	case manager.CHECK_MISSING:
+		P(ERROR, "%v not found %v.\n", check.Folder, util.NODE)
+	case manager.CHECK_VERSION:
+		P(ERROR, "%v node --version is %v, arch is %v, not match folder name.\n", check.Folder, check.Version, check.Arch)
+	case manager.CHECK_CHECKSUM:
+		P(ERROR, "%v sha256 is %v, but %v is %v.\n", check.Folder, check.SHA256, check.From, check.Want)
+	}
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	// go
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+/*
+Custom Node.js build marker file, usage gnvm install --from, e.g. <root>/18.19.0-patched/gnvm-build.json
+*/
+const BUILD = "gnvm-build.json"
+
+/*
+Custom Node.js build
+
+  - Name:    local folder name, e.g. 18.19.0-patched
+  - From:    local archive path or url, e.g. https://internal/builds/node-custom.zip
+  - Version: node.exe --version, e.g. 18.19.0
+  - Arch:    include: "x86" "x64"
+*/
+type Build struct {
+	Name    string `json:"name"`
+	From    string `json:"from"`
+	Version string `json:"version"`
+	Arch    string `json:"arch"`
+}
+
+var (
+	buildReg   = regexp.MustCompile(`^\d+\.\d+\.\d+-[0-9a-z][0-9a-z.]*$`)
+	archiveReg = regexp.MustCompile(`^node-v(\d+\.\d+\.\d+)-win-(x86|x64|arm64)\.zip$`)
+)
+
+/*
+Verify custom build folder name
+
+Param:
+  - name: include: x.xx.xx x.xx.xx-x86|x64 x.xx.xx-<tag>, e.g. 18.19.0-patched
+
+Return:
+  - bool: false when name is latest, global, channel version or invalid
+*/
+func VerifyBuildName(name string) bool {
+	if _, _, _, ok := ParseChannel(name); ok {
+		return false
+	}
+	if name == LATEST || name == GLOBAL || name == UNKNOWN {
+		return false
+	}
+	if _, _, _, _, err := ParseNodeVer(name); err == nil {
+		return true
+	}
+	return buildReg.MatchString(name)
+}
+
+/*
+Return custom build folder name from official archive name
+
+Param:
+  - from: local path or url, e.g. ./node-v18.19.0-win-x64.zip
+
+Return:
+  - name: e.g. 18.19.0 18.19.0-x86, when not official archive name, return ""
+*/
+func BuildName(from string) string {
+	arr := archiveReg.FindStringSubmatch(strings.ToLower(path.Base(filepath.ToSlash(from))))
+	if arr == nil {
+		return ""
+	}
+	return VersionFolder(arr[1], arr[2])
+}
+
+/*
+Return local folder name of Node.js version and arch
+
+Param:
+  - version: x.xx.xx
+  - arch:    include: "x86" "x64" "arm64"
+
+Return:
+  - folder: e.g. x.xx.xx x.xx.xx-x86, suffix is "" when it is current arch
+*/
+func VersionFolder(version, arch string) string {
+	if arch == "x86" && runtime.GOARCH != "386" || arch == "x64" && runtime.GOARCH == "386" {
+		return version + "-" + arch
+	}
+	return version
+}
+
+/*
+Read <root>/<folder>/gnvm-build.json
+
+Param:
+  - root:   gnvm root
+  - folder: e.g. 18.19.0-patched
+
+Return:
+  - *Build
+  - error: when folder is not custom build
+*/
+func ReadBuild(root, folder string) (*Build, error) {
+	file, err := FileSystem.Open(filepath.Join(root, folder, BUILD))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	build := new(Build)
+	if err := json.NewDecoder(file).Decode(build); err != nil {
+		return nil, Errorf(EXIT_ERROR, "parse %v Error: %v", filepath.Join(root, folder, BUILD), err)
+	}
+	return build, nil
+}
+
+/*
+Write <root>/<build.Name>/gnvm-build.json
+*/
+func WriteBuild(root string, build *Build) error {
+	file, err := FileSystem.Create(filepath.Join(root, build.Name, BUILD))
+	if err != nil {
+		return Errorf(EXIT_ERROR, "create %v Error: %v", filepath.Join(root, build.Name, BUILD), err)
+	}
+	err = json.NewEncoder(file).Encode(build)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Errorf(EXIT_ERROR, "write %v Error: %v", filepath.Join(root, build.Name, BUILD), err)
+	}
+	return nil
+}
+
+/*
+Return true when <root>/<folder> is custom build
+*/
+func IsBuild(root, folder string) bool {
+	return IsDirExist(root, folder, BUILD)
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	// go
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+/*
+Node.js release channel, include:
+  - CHANNEL_RELEASE: config registry, e.g. https://nodejs.org/dist/
+  - CHANNEL_RC:      release candidate, e.g. https://nodejs.org/download/rc/
+  - CHANNEL_NIGHTLY: nightly build, e.g. https://nodejs.org/download/nightly/
+  - CHANNEL_TEST:    test build, e.g. https://nodejs.org/download/test/
+  - custom:          config property channel.<name>
+
+Channel version local folder is <channel>@<version>, e.g. rc@22.0.0-rc.1 nightly@22.0.0-nightly20240101abcdef-x86
+*/
+const (
+	CHANNEL_RELEASE = "release"
+	CHANNEL_RC      = "rc"
+	CHANNEL_NIGHTLY = "nightly"
+	CHANNEL_TEST    = "test"
+	CHANNEL_SEP     = "@"
+)
+
+var (
+	channelReg    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	prereleaseReg = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9a-z][0-9a-z.]*)?$`)
+)
+
+/*
+Parse channel version
+
+Param:
+  - s: include: rc nightly test <channel>/latest <channel>/x.xx.xx-<tag> <channel>@x.xx.xx-<tag>-x86|x64
+
+Return:
+  - channel: e.g. rc
+  - version: e.g. 22.0.0-rc.1 latest
+  - suffix:  include: "x86" "x64" ""
+  - ok:      false when s is not channel version, e.g. 20.1.0 latest
+*/
+func ParseChannel(s string) (channel, version, suffix string, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case CHANNEL_RC, CHANNEL_NIGHTLY, CHANNEL_TEST:
+		return s, LATEST, "", true
+	}
+	i := strings.IndexAny(s, "/"+CHANNEL_SEP)
+	if i <= 0 || !channelReg.MatchString(s[:i]) {
+		return "", "", "", false
+	}
+	channel, version = s[:i], s[i+1:]
+	for _, arch := range []string{"x86", "x64"} {
+		if strings.HasSuffix(version, "-"+arch) {
+			version, suffix = strings.TrimSuffix(version, "-"+arch), arch
+		}
+	}
+	if version != LATEST && !prereleaseReg.MatchString(version) {
+		return "", "", "", false
+	}
+	return channel, version, suffix, true
+}
+
+/*
+Return local folder of channel version, when s is not channel version, return s
+
+Param:
+  - s: e.g. rc/22.0.0-rc.1 rc/22.0.0-rc.1-x86
+
+Return:
+  - folder: e.g. rc@22.0.0-rc.1 rc@22.0.0-rc.1-x86, suffix is "" when it is current arch, release channel is x.xx.xx
+*/
+func ChannelFolder(s string) string {
+	channel, version, suffix, ok := ParseChannel(s)
+	if !ok {
+		return s
+	}
+	folder := channel + CHANNEL_SEP + version
+	if channel == CHANNEL_RELEASE {
+		folder = version
+	}
+	if suffix == "x86" && runtime.GOARCH != "386" || suffix == "x64" && runtime.GOARCH != "amd64" {
+		folder += "-" + suffix
+	}
+	return folder
+}
+
+/*
+Remove arch suffix of version, e.g. 20.1.0-x86 to 20.1.0, 22.0.0-rc.1-x64 to 22.0.0-rc.1
+*/
+func TrimArch(version string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(version, "-x86"), "-x64")
+}
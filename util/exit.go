@@ -0,0 +1,114 @@
+package util
+
+import (
+	// go
+	"errors"
+	"fmt"
+	"strings"
+
+	// local
+	. "gnvm/console"
+)
+
+/*
+gnvm.exe exit code, include:
+  - EXIT_OK:            success
+  - EXIT_ERROR:         unknown error
+  - EXIT_USAGE:         usage error, e.g. invalid parameter or flag
+  - EXIT_NETWORK:       network error, e.g. registry unreachable or download fail
+  - EXIT_NOT_INSTALLED: Node.js version or npm not installed
+  - EXIT_CHECKSUM:      checksum mismatch or download size verify error
+  - EXIT_CONFIG:        .gnvmrc read or write error
+  - EXIT_SESSION:       command not support in session environment
+  - EXIT_CANCELLED:     operation has been cancelled
+*/
+const (
+	EXIT_OK = iota
+	EXIT_ERROR
+	EXIT_USAGE
+	EXIT_NETWORK
+	EXIT_NOT_INSTALLED
+	EXIT_CHECKSUM
+	EXIT_CONFIG
+	EXIT_SESSION
+	EXIT_CANCELLED
+)
+
+/*
+Error with exit code
+*/
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Create ExitError
+
+Param:
+  - code:   exit code, e.g. EXIT_USAGE
+  - format: error message format
+  - args:   error message args
+
+Return:
+  - error: *ExitError
+*/
+func Errorf(code int, format string, args ...interface{}) error {
+	return &ExitError{code, fmt.Errorf(format, args...)}
+}
+
+/*
+Print message usage cprint.P and return ExitError
+
+Param:
+  - code:    exit code, e.g. EXIT_USAGE
+  - flag:    cprint flag, include: DEFAULT NOTICE WARING ERROR
+  - message: print message, e.g. "%v not an %v Node.js version. See '%v'.\n"
+  - args:    print message args
+
+Return:
+  - error: *ExitError, message without new line and " See '<help>'." hint, e.g. "5.1 not an valid Node.js version"
+*/
+func Fail(code int, flag, message string, args ...interface{}) error {
+	P(flag, message, args...)
+	text := strings.TrimRight(message, "\n")
+	if i := strings.LastIndex(text, " See '"); i > 0 && strings.HasSuffix(text, "'.") {
+		if strings.HasSuffix(text, "'%v'.") && len(args) > 0 {
+			args = args[:len(args)-1]
+		}
+		text = strings.TrimRight(text[:i], ".,")
+	}
+	return Errorf(code, text, args...)
+}
+
+/*
+Return exit code of error
+
+Param:
+  - err: error
+
+Return:
+  - code: when err == nil, return EXIT_OK, when err is *VersionError, return EXIT_USAGE, other return EXIT_ERROR
+*/
+func ExitCode(err error) int {
+	if err == nil {
+		return EXIT_OK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	var verErr *VersionError
+	if errors.As(err, &verErr) {
+		return EXIT_USAGE
+	}
+	return EXIT_ERROR
+}
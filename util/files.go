@@ -0,0 +1,194 @@
+package util
+
+import (
+	// go
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Node.js Windows build type from index.json files, include:
+  - FILE_EXE: win-<arch>-exe, e.g. v20.1.0/win-x64/node.exe
+  - FILE_ZIP: win-<arch>-zip, e.g. v20.1.0/node-v20.1.0-win-arm64.zip
+*/
+const (
+	FILE_EXE = "exe"
+	FILE_ZIP = "zip"
+)
+
+/*
+Node.js Windows arch, sort by exec column
+*/
+var WIN_ARCHS = []string{"x86", "x64", "arm64"}
+
+/*
+Return Node.js Windows archs which can be installed, exe or zip
+
+Param:
+  - files: index.json files, e.g. ["win-x64-exe", "win-x86-zip", "win-arm64-zip", "osx-x64-tar"]
+
+Return:
+  - archs: e.g. ["x86", "x64", "arm64"]
+*/
+func WinArchs(files []string) []string {
+	archs := []string{}
+	for _, arch := range WIN_ARCHS {
+		if hasFile(files, arch, FILE_EXE) || hasFile(files, arch, FILE_ZIP) {
+			archs = append(archs, arch)
+		}
+	}
+	return archs
+}
+
+/*
+Get remote Node.js download url by index.json files, prefer exe, then zip
+
+Param:
+  - url:     remote Node.js url, e.g. https://nodejs.org/dist/
+  - version: Node.js version, e.g. 20.1.0 20.1.0-x86 22.0.0-rc.1
+  - arch:    include: "386" "amd64" "arm64", when "arm64" not found, usage "amd64"
+  - files:   index.json files, when nil, usage GetRemoteNodePath
+
+Return:
+  - url:  e.g. https://nodejs.org/dist/v20.1.0/win-x64/node.exe
+  - kind: include: FILE_EXE FILE_ZIP
+  - err:  not found any Windows build of arch
+*/
+func GetRemoteNodeBuild(url, version, arch string, files []string) (string, string, error) {
+	version = TrimArch(version)
+	if files == nil {
+		url, err := GetRemoteNodePath(url, version, arch)
+		return url, FILE_EXE, err
+	}
+
+	archs := []string{FormatArch(arch)}
+	if arch == "arm64" {
+		archs = []string{"arm64", "x64"}
+	}
+	for _, a := range archs {
+		if hasFile(files, a, FILE_EXE) {
+			level, exec := GetNodeVerLev(FormatNodeVer(strings.Split(version, "-")[0])), NODE
+			if level == 3 {
+				exec = IOJS
+			}
+			switch {
+			case level <= 2 && a == "x86":
+				return url + "v" + version + "/" + exec, FILE_EXE, nil
+			case level <= 2:
+				return url + "v" + version + "/" + a + "/" + exec, FILE_EXE, nil
+			}
+			return url + "v" + version + "/win-" + a + "/" + exec, FILE_EXE, nil
+		}
+		if hasFile(files, a, FILE_ZIP) {
+			return url + "v" + version + "/node-v" + version + "-win-" + a + ".zip", FILE_ZIP, nil
+		}
+	}
+	return "", "", errors.New("Node.js version " + version + " not any " + strings.Join(archs, " or ") + " Windows build, include: " + strings.Join(WinArchs(files), " "))
+}
+
+/*
+Extract node.exe or <folder>/node.exe from Node.js zip to dst, remove zip when success
+
+Param:
+  - src: zip path, e.g. <root>/20.1.0/node-v20.1.0-win-arm64.zip
+  - dst: folder, e.g. <root>/20.1.0
+
+Return:
+  - error
+*/
+func ExtractNode(src, dst string) error {
+	file, err := FileSystem.Open(src)
+	if err != nil {
+		return Errorf(EXIT_ERROR, "open %v Error: %v", src, err)
+	}
+	body, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return Errorf(EXIT_ERROR, "read %v Error: %v", src, err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return Errorf(EXIT_CHECKSUM, "read %v Error: %v", src, err)
+	}
+	for _, f := range zr.File {
+		if path.Base(f.Name) != NODE || strings.Count(strings.Trim(f.Name, "/"), "/") > 1 {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return Errorf(EXIT_CHECKSUM, "read %v Error: %v", f.Name, err)
+		}
+		defer rc.Close()
+		out, err := FileSystem.Create(filepath.Join(dst, NODE))
+		if err != nil {
+			return Errorf(EXIT_ERROR, "create %v Error: %v", filepath.Join(dst, NODE), err)
+		}
+		_, err = io.Copy(out, rc)
+		out.Close()
+		if err != nil {
+			return Errorf(EXIT_ERROR, "write %v Error: %v", filepath.Join(dst, NODE), err)
+		}
+		FileSystem.Remove(src)
+		return nil
+	}
+	return Errorf(EXIT_CHECKSUM, "not found %v in %v", NODE, src)
+}
+
+/*
+Return SHA-256 hex of file, e.g. <root>/20.1.0/node.exe
+*/
+func HashFile(path string) (string, error) {
+	file, err := FileSystem.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+/*
+Return total size of all files in folder, include sub folders
+*/
+func DirSize(path string) (int64, error) {
+	files, err := FileSystem.ReadDir(path)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, file := range files {
+		if file.IsDir() {
+			n, err := DirSize(filepath.Join(path, file.Name()))
+			if err != nil {
+				return 0, err
+			}
+			size += n
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return 0, err
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
+
+func hasFile(files []string, arch, kind string) bool {
+	for _, f := range files {
+		if f == "win-"+arch+"-"+kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	// go
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Output format, include:
+  - FORMAT_TEXT: coloured human-oriented text, default
+  - FORMAT_JSON: json document
+  - FORMAT_TSV:  tab-separated values, first line is header
+*/
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+	FORMAT_TSV  = "tsv"
+)
+
+var Format = FORMAT_TEXT
+
+/*
+Set output format
+
+Param:
+  - format: include: text json tsv
+
+Return:
+  - error
+*/
+func SetFormat(format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case FORMAT_TEXT, FORMAT_JSON, FORMAT_TSV:
+		Format = format
+		return nil
+	}
+	return errors.New(format + " not a valid format, only support text, json and tsv")
+}
+
+/*
+Return true when output format is text
+*/
+func IsText() bool {
+	return Format == FORMAT_TEXT
+}
+
+/*
+Print structured document by current format
+
+Param:
+  - v:      json document
+  - header: tsv header
+  - rows:   tsv rows
+*/
+func PrintDoc(v interface{}, header []string, rows [][]string) {
+	if Format == FORMAT_TSV {
+		fmt.Println(strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Println(strings.Join(row, "\t"))
+		}
+		return
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(b))
+}
+
+/*
+Format go arch to Node.js arch
+
+Param:
+  - arch: include: "386" "amd64" "x86" "x64"
+
+Return:
+  - arch: include: "x86" "x64"
+*/
+func FormatArch(arch string) string {
+	switch arch {
+	case "386", "x86":
+		return "x86"
+	}
+	return "x64"
+}
+
+/*
+Format bytes to human readable size, e.g. 1536 to 1.5 KB
+
+Param:
+  - n: bytes
+
+Return:
+  - size: include unit B KB MB GB
+*/
+func FormatSize(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	size, unit := float64(n)/1024, "KB"
+	for _, u := range []string{"MB", "GB"} {
+		if size < 1024 {
+			break
+		}
+		size, unit = size/1024, u
+	}
+	return fmt.Sprintf("%.1f %v", size, unit)
+}
+
+/*
+Parse time point before now
+
+Param:
+  - s:   include: <n>d <n>w go duration and date, e.g. 180d 2w 36h 2024-01-01
+  - now: current time
+
+Return:
+  - time: e.g. 180d is now - 180 days
+  - error
+*/
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	for unit, day := range map[string]int{"d": 1, "w": 7} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, unit)); strings.HasSuffix(s, unit) && err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n*day), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, errors.New(s + " not a valid time, e.g. 180d 2w 36h 2024-01-01")
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	// go
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	// local
+	. "gnvm/console"
+)
+
+/*
+Confirm prompt answer, include:
+  - ANSWER_ASK: read answer from Stdin, default
+  - ANSWER_YES: answer yes to all prompts, flag --yes
+  - ANSWER_NO:  answer no to all prompts, flag --no
+*/
+const (
+	ANSWER_ASK = iota
+	ANSWER_YES
+	ANSWER_NO
+)
+
+/*
+Prompt input, include:
+  - Answer:      see ANSWER_ASK
+  - Stdin:       default os.Stdin, replace it in tests
+  - Interactive: default true when os.Stdin is a terminal( character device )
+*/
+var (
+	Answer                = ANSWER_ASK
+	Stdin       io.Reader = os.Stdin
+	Interactive           = isTerminal(os.Stdin)
+)
+
+/*
+Print [Y/n] prompt and read answer
+
+Param:
+  - message: prompt message, e.g. "download %v version [Y/n]? "
+  - args:    prompt message args
+
+Return:
+  - bool:  true when answer is y
+  - error: EXIT_USAGE when stdin not interactive( or closed ) and not answer by --yes or --no
+*/
+func Confirm(message string, args ...interface{}) (bool, error) {
+	P(NOTICE, message, args...)
+	switch {
+	case Answer == ANSWER_YES:
+		echo("y\n")
+		return true, nil
+	case Answer == ANSWER_NO:
+		echo("n\n")
+		return false, nil
+	case !Interactive:
+		echo("\n")
+		return false, notInteractive()
+	}
+	prompt := "n"
+	if _, err := fmt.Fscanf(Stdin, "%s\n", &prompt); err == io.EOF {
+		echo("\n")
+		return false, notInteractive()
+	}
+	return strings.ToLower(prompt) == "y", nil
+}
+
+func notInteractive() error {
+	return Fail(EXIT_USAGE, ERROR, "stdin is not interactive, please use '%v' or '%v' to answer the prompt.\n", "--yes", "--no")
+}
+
+/*
+Print answer after prompt, when prompt printed
+*/
+func echo(answer string) {
+	if Enabled(NOTICE) {
+		P(DEFAULT, answer)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
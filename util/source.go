@@ -0,0 +1,125 @@
+package util
+
+import (
+	// go
+	"encoding/json"
+	"path/filepath"
+	"time"
+)
+
+/*
+Node.js install source marker file, write after download success, e.g. <root>/18.19.0/gnvm-source.json
+*/
+const SOURCE = "gnvm-source.json"
+
+/*
+Node.js last used marker file, write by gnvm use, e.g. <root>/18.19.0/gnvm-used.json
+*/
+const USED = "gnvm-used.json"
+
+/*
+Node.js install source
+
+  - Registry: Node.js registry, e.g. https://nodejs.org/dist/
+  - URL:      download url, e.g. https://nodejs.org/dist/v18.19.0/win-x64/node.exe
+  - Date:     install date, RFC3339 format
+  - SHA256:   SHA-256 of node.exe after install, usage gnvm verify
+*/
+type Source struct {
+	Registry string `json:"registry"`
+	URL      string `json:"url"`
+	Date     string `json:"date"`
+	SHA256   string `json:"sha256"`
+}
+
+/*
+Create Source, Date is now
+*/
+func NewSource(registry, url string) *Source {
+	return &Source{registry, url, time.Now().UTC().Format(time.RFC3339), ""}
+}
+
+/*
+Read <root>/<folder>/gnvm-source.json
+
+Return:
+  - *Source
+  - error: when folder not install by gnvm install, e.g. gnvm import
+*/
+func ReadSource(root, folder string) (*Source, error) {
+	file, err := FileSystem.Open(filepath.Join(root, folder, SOURCE))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	source := new(Source)
+	if err := json.NewDecoder(file).Decode(source); err != nil {
+		return nil, Errorf(EXIT_ERROR, "parse %v Error: %v", filepath.Join(root, folder, SOURCE), err)
+	}
+	return source, nil
+}
+
+/*
+Write <root>/<folder>/gnvm-source.json, when SHA256 == "", usage SHA-256 of <root>/<folder>/node.exe
+*/
+func WriteSource(root, folder string, source *Source) error {
+	if source.SHA256 == "" {
+		sum, err := HashFile(filepath.Join(root, folder, NODE))
+		if err != nil {
+			return Errorf(EXIT_ERROR, "hash %v Error: %v", filepath.Join(root, folder, NODE), err)
+		}
+		source.SHA256 = sum
+	}
+	path := filepath.Join(root, folder, SOURCE)
+	file, err := FileSystem.Create(path)
+	if err != nil {
+		return Errorf(EXIT_ERROR, "create %v Error: %v", path, err)
+	}
+	err = json.NewEncoder(file).Encode(source)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Errorf(EXIT_ERROR, "write %v Error: %v", path, err)
+	}
+	return nil
+}
+
+/*
+Write <root>/<folder>/gnvm-used.json, date is now
+*/
+func WriteUsed(root, folder string) error {
+	path := filepath.Join(root, folder, USED)
+	file, err := FileSystem.Create(path)
+	if err != nil {
+		return Errorf(EXIT_ERROR, "create %v Error: %v", path, err)
+	}
+	err = json.NewEncoder(file).Encode(map[string]string{"date": time.Now().UTC().Format(time.RFC3339)})
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Errorf(EXIT_ERROR, "write %v Error: %v", path, err)
+	}
+	return nil
+}
+
+/*
+Return last used time of <root>/<folder>
+
+Return:
+  - time
+  - error: when folder never used by gnvm use
+*/
+func ReadUsed(root, folder string) (time.Time, error) {
+	file, err := FileSystem.Open(filepath.Join(root, folder, USED))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer file.Close()
+	used := map[string]string{}
+	if err := json.NewDecoder(file).Decode(&used); err != nil {
+		return time.Time{}, Errorf(EXIT_ERROR, "parse %v Error: %v", filepath.Join(root, folder, USED), err)
+	}
+	return time.Parse(time.RFC3339, used["date"])
+}
@@ -0,0 +1,120 @@
+package util
+
+import (
+	// go
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+
+	// local
+	. "gnvm/console"
+)
+
+/*
+File system, usage FileSystem, replace it in tests
+*/
+type FS interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.DirEntry, error)
+	Open(name string) (io.ReadCloser, error)
+	Create(name string) (io.WriteCloser, error)
+	MkdirAll(path string, perm os.FileMode) error
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+	RemoveAll(path string) error
+	Link(oldname, newname string) error
+}
+
+/*
+Process execution, usage Runner, replace it in tests
+*/
+type Exec interface {
+	Output(name string, args ...string) ([]byte, error)
+}
+
+/*
+Injectable dependencies, include:
+  - FileSystem: default os
+  - Runner:     default os/exec
+  - HTTPClient: default http.DefaultClient, proxy and timeout from .gnvmrc
+*/
+var (
+	FileSystem FS           = OSFS{}
+	Runner     Exec         = OSExec{}
+	HTTPClient *http.Client = http.DefaultClient
+)
+
+/*
+FS implementation by os
+*/
+type OSFS struct{}
+
+func (OSFS) Stat(name string) (os.FileInfo, error)        { return os.Stat(name) }
+func (OSFS) ReadDir(name string) ([]os.DirEntry, error)   { return os.ReadDir(name) }
+func (OSFS) Open(name string) (io.ReadCloser, error)      { return os.Open(name) }
+func (OSFS) Create(name string) (io.WriteCloser, error)   { return os.Create(name) }
+func (OSFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+func (OSFS) Rename(oldpath, newpath string) error         { return os.Rename(oldpath, newpath) }
+func (OSFS) Remove(name string) error                     { return os.Remove(name) }
+func (OSFS) RemoveAll(path string) error                  { return os.RemoveAll(path) }
+
+/*
+Create hard link, when fail, e.g. cross volume, create symbolic link
+*/
+func (OSFS) Link(oldname, newname string) error {
+	if err := os.Link(oldname, newname); err != nil {
+		return os.Symlink(oldname, newname)
+	}
+	return nil
+}
+
+/*
+Exec implementation by os/exec
+*/
+type OSExec struct{}
+
+func (OSExec) Output(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+/*
+http.RoundTripper print request and response with DEBUG level, usage --debug
+*/
+type DebugTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	Debug("%v %v\n", req.Method, req.URL.String())
+	res, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		Debug("%v %v Error: %v\n", req.Method, req.URL.String(), err.Error())
+		return nil, err
+	}
+	Debug("%v %v %v\n", req.Method, req.URL.String(), res.Status)
+	return res, nil
+}
+
+/*
+Http get usage HTTPClient
+
+Param:
+  - url: e.g. https://nodejs.org/dist/index.json
+
+Return:
+  - code: 0: success, -1: status code != 200, -5: request error, the same as curl.Get
+  - res:  *http.Response
+  - err
+*/
+func Get(url string) (int, *http.Response, error) {
+	res, err := HTTPClient.Get(url)
+	if err != nil {
+		return -5, nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return -1, nil, Errorf(EXIT_NETWORK, "%v an [%v] error occurred.", url, res.StatusCode)
+	}
+	return 0, res, nil
+}
@@ -3,7 +3,6 @@ package util
 import (
 
 	// lib
-	. "github.com/Kenshin/cprint"
 	"github.com/Kenshin/curl"
 
 	// go
@@ -17,6 +16,9 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+
+	// local
+	. "gnvm/console"
 )
 
 const (
@@ -34,14 +36,33 @@ const (
 	ORIGIN_HUAWEI  = "https://mirrors.huaweicloud.com/nodejs/"
 	NODELIST       = "index.json"
 	SHASUMS        = "SHASUMS256.txt"
+	SCHEDULE       = "schedule.json"
+	ORIGIN_RELEASE = "https://raw.githubusercontent.com/nodejs/Release/main/"
 )
 
 var DIVIDE = string(os.PathSeparator)
 
 /*
-Golbal node.exe path
+Golbal node.exe path and resolution method, include:
+  - ROOT_FLAG:    --root flag
+  - ROOT_ENV:     GNVM_HOME environment variable
+  - ROOT_SESSION: GNVM_SESSION_NODE_HOME environment variable
+  - ROOT_NODE:    node.exe folder from Path
+  - ROOT_GNVM:    gnvm.exe folder from Path
+  - ROOT_CWD:     current folder
 */
-var GlobalNodePath string
+var GlobalNodePath, RootSource string
+
+const (
+	GNVM_HOME = "GNVM_HOME"
+
+	ROOT_FLAG    = "--root"
+	ROOT_ENV     = GNVM_HOME
+	ROOT_SESSION = "session"
+	ROOT_NODE    = NODE
+	ROOT_GNVM    = GNVM
+	ROOT_CWD     = "current folder"
+)
 
 func init() {
 
@@ -49,15 +70,15 @@ func init() {
 	defer func() {
 		if err := recover(); err != nil {
 			Error(ERROR, "initialize gnvm.exe an error has occurred. please check. \nError: ", err)
-			os.Exit(0)
+			os.Exit(EXIT_ERROR)
 		}
 	}()
 
-	GlobalNodePath = getGlobalNodePath()
+	GlobalNodePath, RootSource = getGlobalNodePath()
 }
 
 /*
-	  Get Node.js version, usage exec.Command()
+	  Get Node.js version, usage Runner
 
 	  Param:
 		- path:   node.exe path, e.g. x:\xxx\xxx
@@ -68,7 +89,7 @@ func init() {
 */
 func GetNodeVer(path string) (string, error) {
 	FormatPath(&path)
-	out, err := exec.Command(path+NODE, "--version").Output()
+	out, err := Runner.Output(path+NODE, "--version")
 	if err == nil {
 		return strings.TrimSpace(string(out[1:])), nil
 	}
@@ -86,6 +107,9 @@ func GetNodeVer(path string) (string, error) {
 		- bool:    true or false
 */
 func VerifyNodeVer(version string) bool {
+	if _, _, _, ok := ParseChannel(version); ok {
+		return true
+	}
 	version = strings.Split(version, "-")[0]
 	version = strings.TrimSpace(version)
 	version = strings.ToLower(version)
@@ -167,7 +191,7 @@ func FormatWildcard(version, url string) (*regexp.Regexp, error) {
 	} else if ok, _ := regexp.MatchString(reg3, version); ok {
 		return regexp.Compile(`^` + strings.Replace(version, "*", "", -1) + `([0]|[1-9]\d?)$`)
 	} else {
-		return nil, errors.New("parameter format error.")
+		return nil, &VersionError{version, ErrInvalidVersion}
 	}
 }
 
@@ -224,23 +248,37 @@ func GetNodeVerLev(ver float64) (level int) {
 	 	s support format: <version>-<arch>, e.g.
 		- x.xx.xx
 	 	- x.xx.xx-x86|x64
+		- <channel>/x.xx.xx-<tag>-x86|x64, see ParseChannel
 
 	 Return:
-		- ver    : x.xx.xx
+		- ver    : x.xx.xx or <channel>@<version>, e.g. rc@22.0.0-rc.1
 		- iojs   : true  and false
 		- arch   : "386" and "amd64"
 		- suffix : "x86" and "x64"  and ""
-		- err    : includ, "1" "2", "3", "4", "5"
+		- err    : *VersionError, include: ErrUnsupportedVersion ErrArchSuffix ErrVersionFormat ErrInvalidVersion ErrNPMVersion
 */
 func ParseNodeVer(s string) (ver string, iojs bool, arch, suffix string, err error) {
 	arr := strings.Split(strings.ToLower(s), "-")
 
+	// channel version, e.g. rc/22.0.0-rc.1-x86 to rc@22.0.0-rc.1 and x86, release/x.xx.xx to x.xx.xx
+	channel, version, chSuffix, isChannel := ParseChannel(s)
+	if isChannel {
+		if channel != CHANNEL_RELEASE {
+			version = channel + CHANNEL_SEP + version
+		}
+		arr = []string{version}
+		if chSuffix != "" {
+			arr = append(arr, chSuffix)
+		}
+		isChannel = channel != CHANNEL_RELEASE
+	}
+
 	// get ver
 	ver = arr[0]
 
 	// verify npm
 	if ver == NPM {
-		err = errors.New("5")
+		err = &VersionError{s, ErrNPMVersion}
 		return
 	}
 
@@ -257,16 +295,17 @@ func ParseNodeVer(s string) (ver string, iojs bool, arch, suffix string, err err
 
 	// verify ver
 	if !VerifyNodeVer(ver) {
-		err = errors.New("4")
+		err = &VersionError{s, ErrInvalidVersion}
 		return
 	}
 
-	switch GetNodeVerLev(FormatNodeVer(ver)) {
-	case 0:
+	switch level := GetNodeVerLev(FormatNodeVer(ver)); {
+	case isChannel:
+	case level == 0:
 		// no exec
-		err = errors.New("1")
+		err = &VersionError{s, ErrUnsupportedVersion}
 		return
-	case 3:
+	case level == 3:
 		// get iojs
 		iojs = true
 	}
@@ -276,11 +315,11 @@ func ParseNodeVer(s string) (ver string, iojs bool, arch, suffix string, err err
 		if ok, _ := regexp.MatchString(`^x?(86|64)$`, arr[1]); ok {
 			arch = arr[1]
 		} else {
-			err = errors.New("2")
+			err = &VersionError{s, ErrArchSuffix}
 			return
 		}
 	} else if len(arr) > 2 {
-		err = errors.New("3")
+		err = &VersionError{s, ErrVersionFormat}
 		return
 	}
 
@@ -321,8 +360,8 @@ func GetLatVer(url string) string {
 
 	var version string
 
-	// curl
-	code, res, _ := curl.Get(url)
+	// get
+	code, res, _ := Get(url)
 	if code != 0 {
 		return ""
 	}
@@ -358,8 +397,8 @@ func GetLatVer(url string) string {
 		- url:     remote node.exe url, e.g. https://cdn.npmmirror.com/binaries/node/v5.9.0/win-x64/node.exe
 */
 func GetRemoteNodePath(url, version, arch string) (string, error) {
-	version = strings.Split(version, "-")[0]
-	folder, exec, level := "/", NODE, GetNodeVerLev(FormatNodeVer(version))
+	version = TrimArch(version)
+	folder, exec, level := "/", NODE, GetNodeVerLev(FormatNodeVer(strings.Split(version, "-")[0]))
 
 	switch level {
 	case 0:
@@ -399,7 +438,7 @@ func GetRemoteNodePath(url, version, arch string) (string, error) {
 */
 func Arch(path string) (string, error) {
 	FormatPath(&path)
-	f, err := os.Open(path + NODE)
+	f, err := FileSystem.Open(path + NODE)
 	if err != nil {
 		return "", err
 	}
@@ -429,7 +468,6 @@ func Arch(path string) (string, error) {
 			return "x64", nil
 		}
 	}
-	return "x64", nil
 }
 
 /*
@@ -501,12 +539,12 @@ func FormatPath(path *string) {
 func Copy(src, dst, name string) (err error) {
 	src = src + DIVIDE + name
 	dst = dst + DIVIDE + name
-	in, err := os.Open(src)
+	in, err := FileSystem.Open(src)
 	if err != nil {
 		return
 	}
 	defer in.Close()
-	out, err := os.Create(dst)
+	out, err := FileSystem.Create(dst)
 	if err != nil {
 		return
 	}
@@ -519,7 +557,9 @@ func Copy(src, dst, name string) (err error) {
 	if _, err = io.Copy(out, in); err != nil {
 		return
 	}
-	err = out.Sync()
+	if f, ok := out.(*os.File); ok {
+		err = f.Sync()
+	}
 	return
 }
 
@@ -535,40 +575,130 @@ Return:
 */
 func IsDirExist(paths ...string) bool {
 	path := filepath.Join(paths...)
-	_, err := os.Stat(path)
+	_, err := FileSystem.Stat(path)
 	if err != nil && os.IsNotExist(err) {
 		return false
 	}
 	return true
 }
 
-func getGlobalNodePath() string {
-	var path string
+/*
+Set gnvm root path, usage --root flag, highest priority
+
+Param:
+  - path: gnvm root path, must be exist folder
+
+Return:
+  - error
+*/
+func SetRoot(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if fi, err := os.Stat(path); err != nil || !fi.IsDir() {
+		return errors.New(path + " folder is not exist")
+	}
+	GlobalNodePath, RootSource = strings.TrimSuffix(path, DIVIDE), ROOT_FLAG
+	return nil
+}
+
+/*
+Verify gnvm root path is exist folder when resolve by GNVM_HOME, the same as SetRoot
+
+Return:
+  - error
+*/
+func VerifyRoot() error {
+	if RootSource != ROOT_ENV {
+		return nil
+	}
+	if fi, err := os.Stat(GlobalNodePath); err != nil || !fi.IsDir() {
+		return errors.New(GlobalNodePath + " folder is not exist")
+	}
+	return nil
+}
+
+/*
+Return gnvm root path of each resolution method, usage 'gnvm doctor'
 
+Return:
+  - map: key include ROOT_ENV ROOT_SESSION ROOT_NODE ROOT_GNVM ROOT_CWD, when not found, not include key
+*/
+func RootCandidates() map[string]string {
+	candidates := make(map[string]string)
+	if path := getEnvPath(); path != "" {
+		candidates[ROOT_ENV] = path
+	}
+	if path := getSessionPath(); path != "" {
+		candidates[ROOT_SESSION] = path
+	}
+	if path := getLookPath(NODE); path != "" {
+		candidates[ROOT_NODE] = path
+	}
+	if path := getLookPath(GNVM); path != "" {
+		candidates[ROOT_GNVM] = path
+	}
+	if path, err := os.Getwd(); err == nil {
+		candidates[ROOT_CWD] = path
+	}
+	return candidates
+}
+
+/*
+Return gnvm root path and resolution method, priority is GNVM_HOME > session > node.exe > gnvm.exe > current path
+*/
+func getGlobalNodePath() (string, string) {
+	if path := getEnvPath(); path != "" {
+		return path, ROOT_ENV
+	}
+
+	if path := getSessionPath(); path != "" {
+		return path, ROOT_SESSION
+	}
+
+	if path := getLookPath(NODE); path != "" {
+		return path, ROOT_NODE
+	}
+
+	if path := getLookPath(GNVM); path != "" {
+		return path, ROOT_GNVM
+	}
+
+	return getCurrentPath(), ROOT_CWD
+}
+
+func getEnvPath() string {
+	if path := os.Getenv(GNVM_HOME); path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			return strings.TrimSuffix(abs, DIVIDE)
+		}
+	}
+	return ""
+}
+
+func getSessionPath() string {
+	var path string
 	if env, ok := IsSessionEnv("", false); ok {
 		if reg, err := regexp.Compile(`\\([0]|[1-9]\d?)(\.([0]|[1-9]\d?)){2}(-x(86|64))?\\$`); err == nil {
 			ver := reg.FindString(env)
 			path = strings.Replace(env, ver, "", -1)
 		}
-		return path
 	}
+	return path
+}
 
-	file, err := exec.LookPath(NODE)
+func getLookPath(name string) string {
+	file, err := exec.LookPath(name)
 	if err != nil {
-		if file, err := exec.LookPath(GNVM); err != nil {
-			path = getCurrentPath()
-		} else {
-			path = strings.Replace(file, DIVIDE+GNVM, "", -1)
-		}
-	} else {
-		path = strings.Replace(file, DIVIDE+NODE, "", -1)
+		return ""
 	}
+	path := strings.Replace(file, DIVIDE+name, "", -1)
 
 	// gnvm.exe and node.exe the same path
-	if path == "." {
+	if path == "." || path == name {
 		path = getCurrentPath()
 	}
-
 	return path
 }
 
@@ -0,0 +1,410 @@
+package util_test
+
+import (
+	// go
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	// local
+	. "gnvm/console"
+	"gnvm/internal/fake"
+	"gnvm/util"
+)
+
+func TestVerifyNodeVer(t *testing.T) {
+	tests := map[string]bool{
+		"5.10.1":         true,
+		"0.10.28":        true,
+		"1.0.0-x86":      true,
+		"latest":         true,
+		"GLOBAL":         true,
+		"5.10":           false,
+		"05.1.1":         false,
+		"100.1.1":        false,
+		"5.10.1.1":       false,
+		"v5.10.1":        false,
+		"node-5.1.1":     false,
+		"rc/22.0.0-rc.1": true,
+		"nightly":        true,
+		"rc/22.0":        false,
+	}
+	for version, want := range tests {
+		if got := util.VerifyNodeVer(version); got != want {
+			t.Errorf("VerifyNodeVer(%v) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestParseNodeVer(t *testing.T) {
+	tests := []struct {
+		s, ver, arch, suffix string
+		io                   bool
+		err                  error
+	}{
+		{"20.1.0", "20.1.0", runtime.GOARCH, "", false, nil},
+		{"1.0.0", "1.0.0", runtime.GOARCH, "", true, nil},
+		{"20.1.0-x86", "20.1.0", "386", suffix("386"), false, nil},
+		{"20.1.0-x64", "20.1.0", "amd64", suffix("amd64"), false, nil},
+		{"0.1.0", "0.1.0", "", "", false, util.ErrUnsupportedVersion},
+		{"20.1.0-x32", "20.1.0", "", "", false, util.ErrArchSuffix},
+		{"20.1.0-x86-x64", "20.1.0", "", "", false, util.ErrVersionFormat},
+		{"abc", "abc", "", "", false, util.ErrInvalidVersion},
+		{"npm", "npm", "", "", false, util.ErrNPMVersion},
+		{"rc/22.0.0-rc.1", "rc@22.0.0-rc.1", runtime.GOARCH, "", false, nil},
+		{"RC@22.0.0-rc.1-x86", "rc@22.0.0-rc.1", "386", suffix("386"), false, nil},
+		{"nightly", "nightly@latest", runtime.GOARCH, "", false, nil},
+		{"release/20.1.0", "20.1.0", runtime.GOARCH, "", false, nil},
+		{"22.0.0-rc.1", "22.0.0", "", "", false, util.ErrArchSuffix},
+	}
+	for _, test := range tests {
+		ver, io, arch, suffix, err := util.ParseNodeVer(test.s)
+		if test.err != nil {
+			var verErr *util.VersionError
+			if !errors.Is(err, test.err) || !errors.As(err, &verErr) || verErr.Input != test.s {
+				t.Errorf("ParseNodeVer(%v) err = %v, want %v", test.s, err, test.err)
+			}
+			if code := util.ExitCode(err); code != util.EXIT_USAGE {
+				t.Errorf("ParseNodeVer(%v) exit code = %v", test.s, code)
+			}
+			continue
+		}
+		if err != nil || ver != test.ver || io != test.io || arch != test.arch || suffix != test.suffix {
+			t.Errorf("ParseNodeVer(%v) = %v, %v, %v, %v, %v", test.s, ver, io, arch, suffix, err)
+		}
+	}
+}
+
+func suffix(arch string) string {
+	if arch == runtime.GOARCH {
+		return ""
+	}
+	if arch == "386" {
+		return "x86"
+	}
+	return "x64"
+}
+
+func TestParseChannel(t *testing.T) {
+	tests := []struct {
+		s, channel, version, suffix, folder string
+		ok                                  bool
+	}{
+		{"nightly", "nightly", "latest", "", "nightly@latest", true},
+		{"rc/22.0.0-rc.1", "rc", "22.0.0-rc.1", "", "rc@22.0.0-rc.1", true},
+		{"rc@22.0.0-rc.1-x86", "rc", "22.0.0-rc.1", "x86", "rc@22.0.0-rc.1" + suffixOf("386"), true},
+		{"beta/latest", "beta", "latest", "", "beta@latest", true},
+		{"release/20.1.0", "release", "20.1.0", "", "20.1.0", true},
+		{"20.1.0", "", "", "", "20.1.0", false},
+		{"latest", "", "", "", "latest", false},
+		{"rc/abc", "", "", "", "rc/abc", false},
+		{"/v1/", "", "", "", "/v1/", false},
+	}
+	for _, test := range tests {
+		channel, version, suffix, ok := util.ParseChannel(test.s)
+		if channel != test.channel || version != test.version || suffix != test.suffix || ok != test.ok {
+			t.Errorf("ParseChannel(%v) = %v, %v, %v, %v", test.s, channel, version, suffix, ok)
+		}
+		if folder := util.ChannelFolder(test.s); folder != test.folder {
+			t.Errorf("ChannelFolder(%v) = %v, want %v", test.s, folder, test.folder)
+		}
+	}
+}
+
+func suffixOf(arch string) string {
+	if s := suffix(arch); s != "" {
+		return "-" + s
+	}
+	return ""
+}
+
+func TestBuildName(t *testing.T) {
+	names := map[string]string{
+		"./node-v18.19.0-win-x64.zip":                  "18.19.0" + suffixOf("amd64"),
+		"https://nodejs.org/node-v18.19.0-win-x86.zip": "18.19.0" + suffixOf("386"),
+		"C:/builds/node-v20.1.0-win-arm64.zip":         "20.1.0",
+		"https://internal/builds/node-custom.zip":      "",
+		"node-v18.19.0-linux-x64.tar.gz":               "",
+	}
+	for from, want := range names {
+		if got := util.BuildName(from); got != want {
+			t.Errorf("BuildName(%v) = %v, want %v", from, got, want)
+		}
+	}
+	valid := map[string]bool{
+		"18.19.0":         true,
+		"18.19.0-x86":     true,
+		"18.19.0-patched": true,
+		"18.19.0-rc.1":    true,
+		"latest":          false,
+		"rc@22.0.0-rc.1":  false,
+		"patched":         false,
+		"18.19-patched":   false,
+		"18.19.0-a/b":     false,
+	}
+	for name, want := range valid {
+		if got := util.VerifyBuildName(name); got != want {
+			t.Errorf("VerifyBuildName(%v) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestFormatWildcard(t *testing.T) {
+	reg := fake.NewRegistry(t, nil)
+	tests := []struct {
+		version string
+		match   []string
+		miss    []string
+	}{
+		{"*.*.*", []string{"0.10.1", "20.1.0"}, []string{"20.1"}},
+		{"18.*.*", []string{"18.0.0", "18.16.0"}, []string{"1.8.0", "16.20.0"}},
+		{"16.20.X", []string{"16.20.0", "16.20.11"}, []string{"16.2.0"}},
+		{"/^2/", []string{"20.1.0"}, []string{"18.16.0"}},
+		{"latest", []string{"20.1.0"}, []string{"18.16.0"}},
+	}
+	for _, test := range tests {
+		regex, err := util.FormatWildcard(test.version, reg.URL+util.LATEST+"/"+util.SHASUMS)
+		if err != nil {
+			t.Fatalf("FormatWildcard(%v) err %v", test.version, err)
+		}
+		for _, v := range test.match {
+			if !regex.MatchString(v) {
+				t.Errorf("FormatWildcard(%v) not match %v", test.version, v)
+			}
+		}
+		for _, v := range test.miss {
+			if regex.MatchString(v) {
+				t.Errorf("FormatWildcard(%v) match %v", test.version, v)
+			}
+		}
+	}
+	if _, err := util.FormatWildcard("1.*", ""); !errors.Is(err, util.ErrInvalidVersion) {
+		t.Errorf("FormatWildcard(1.*) err %v", err)
+	}
+}
+
+func TestGetLatVer(t *testing.T) {
+	reg := fake.NewRegistry(t, nil)
+	if latest := util.GetLatVer(reg.URL + util.LATEST + "/" + util.SHASUMS); latest != "20.1.0" {
+		t.Fatalf("GetLatVer() = %v", latest)
+	}
+	if latest := util.GetLatVer(reg.URL + "none/" + util.SHASUMS); latest != "" {
+		t.Fatalf("GetLatVer() 404 = %v", latest)
+	}
+}
+
+func TestGetRemoteNodePath(t *testing.T) {
+	tests := []struct {
+		version, arch, want string
+	}{
+		{"20.1.0", "amd64", util.ORIGIN_DEFAULT + "v20.1.0/win-x64/node.exe"},
+		{"20.1.0-x86", "386", util.ORIGIN_DEFAULT + "v20.1.0/win-x86/node.exe"},
+		{"0.10.28", "amd64", util.ORIGIN_DEFAULT + "v0.10.28/x64/node.exe"},
+		{"0.10.28", "386", util.ORIGIN_DEFAULT + "v0.10.28/node.exe"},
+		{"1.0.0", "amd64", util.ORIGIN_DEFAULT + "v1.0.0/win-x64/iojs.exe"},
+	}
+	for _, test := range tests {
+		if got, err := util.GetRemoteNodePath(util.ORIGIN_DEFAULT, test.version, test.arch); err != nil || got != test.want {
+			t.Errorf("GetRemoteNodePath(%v, %v) = %v, %v, want %v", test.version, test.arch, got, err, test.want)
+		}
+	}
+}
+
+func TestGetRemoteNodeBuild(t *testing.T) {
+	tests := []struct {
+		version, arch string
+		files         []string
+		want, kind    string
+	}{
+		{"20.1.0", "amd64", nil, "v20.1.0/win-x64/node.exe", util.FILE_EXE},
+		{"20.1.0", "amd64", []string{"win-x86-exe", "win-x64-zip"}, "v20.1.0/node-v20.1.0-win-x64.zip", util.FILE_ZIP},
+		{"20.1.0-x86", "386", []string{"win-x86-exe", "win-x64-zip"}, "v20.1.0/win-x86/node.exe", util.FILE_EXE},
+		{"20.1.0", "arm64", []string{"win-arm64-zip", "win-x64-exe"}, "v20.1.0/node-v20.1.0-win-arm64.zip", util.FILE_ZIP},
+		{"18.16.0", "arm64", []string{"win-x64-exe"}, "v18.16.0/win-x64/node.exe", util.FILE_EXE},
+		{"0.12.0", "amd64", []string{"win-x64-exe", "win-x86-exe"}, "v0.12.0/x64/node.exe", util.FILE_EXE},
+		{"0.12.0", "386", []string{"win-x64-exe", "win-x86-exe"}, "v0.12.0/node.exe", util.FILE_EXE},
+		{"1.0.0", "amd64", []string{"win-x64-exe"}, "v1.0.0/win-x64/iojs.exe", util.FILE_EXE},
+	}
+	for _, test := range tests {
+		got, kind, err := util.GetRemoteNodeBuild(util.ORIGIN_DEFAULT, test.version, test.arch, test.files)
+		if err != nil || got != util.ORIGIN_DEFAULT+test.want || kind != test.kind {
+			t.Errorf("GetRemoteNodeBuild(%v, %v, %v) = %v, %v, %v", test.version, test.arch, test.files, got, kind, err)
+		}
+	}
+	if _, _, err := util.GetRemoteNodeBuild(util.ORIGIN_DEFAULT, "20.1.0", "386", []string{"win-x64-exe", "osx-x64-tar"}); err == nil {
+		t.Error("GetRemoteNodeBuild without win-x86 build, err is nil")
+	}
+	if archs := strings.Join(util.WinArchs([]string{"win-arm64-zip", "win-x64-exe", "win-x64-zip", "linux-x64"}), " "); archs != "x64 arm64" {
+		t.Errorf("WinArchs() = %v", archs)
+	}
+}
+
+func TestExtractNode(t *testing.T) {
+	dst := t.TempDir()
+	src := filepath.Join(dst, "node-v20.1.0-win-arm64.zip")
+	if err := os.WriteFile(src, fake.Zip(t, "20.1.0", "arm64"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.ExtractNode(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	if body, err := os.ReadFile(filepath.Join(dst, util.NODE)); err != nil || string(body) != string(fake.Node("20.1.0", "arm64")) {
+		t.Fatalf("node.exe = %q, %v", body, err)
+	}
+	if util.IsDirExist(src) {
+		t.Fatal("zip exist after ExtractNode")
+	}
+	if err := os.WriteFile(src, []byte("not zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.ExtractNode(src, dst); util.ExitCode(err) != util.EXIT_CHECKSUM {
+		t.Fatalf("ExtractNode() not zip, err %v", err)
+	}
+}
+
+func TestGetNodeVerAndArch(t *testing.T) {
+	root, exec := fake.Root(t)
+	fake.Install(t, root, "", "18.16.0", "x86")
+
+	if ver, err := util.GetNodeVer(root); err != nil || ver != "18.16.0" {
+		t.Fatalf("GetNodeVer() = %v, %v", ver, err)
+	}
+	if len(exec.Calls) != 1 {
+		t.Fatalf("exec calls %v", exec.Calls)
+	}
+	if arch, err := util.Arch(root); err != nil || arch != "x86" {
+		t.Fatalf("Arch() = %v, %v", arch, err)
+	}
+	if _, err := util.GetNodeVer(t.TempDir()); err == nil {
+		t.Fatal("GetNodeVer() without node.exe, err is nil")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := util.ExitCode(nil); code != util.EXIT_OK {
+		t.Errorf("ExitCode(nil) = %v", code)
+	}
+	err := util.Errorf(util.EXIT_NETWORK, "get %v error", "index.json")
+	if code := util.ExitCode(err); code != util.EXIT_NETWORK || err.Error() != "get index.json error" {
+		t.Errorf("ExitCode(%v) = %v", err, code)
+	}
+
+	stdout, stderr, lv := Stdout, Stderr, Level
+	defer func() { Stdout, Stderr, Level = stdout, stderr, lv }()
+	out := new(bytes.Buffer)
+	Stdout, Stderr, Level = out, out, LEVEL_NORMAL
+	for _, test := range []struct {
+		message string
+		args    []interface{}
+		text    string
+	}{
+		{"%v. See '%v'.\n", []interface{}{"5.1 not an valid Node.js version", "gnvm help install"}, "5.1 not an valid Node.js version"},
+		{"flag %v depends on %v flag, e.g. '%v', See '%v'.\n", []interface{}{"--as", "--from", "gnvm install --from <url>", "gnvm help install"}, "flag --as depends on --from flag, e.g. 'gnvm install --from <url>'"},
+		{"%v folder not exist. See 'gnvm ls'.\n", []interface{}{"5.1.1"}, "5.1.1 folder not exist"},
+		{"operation has been cancelled.\n", nil, "operation has been cancelled."},
+	} {
+		out.Reset()
+		err := util.Fail(util.EXIT_USAGE, ERROR, test.message, test.args...)
+		if err.Error() != test.text || util.ExitCode(err) != util.EXIT_USAGE || !strings.Contains(out.String(), " See ") != (test.args == nil) {
+			t.Errorf("Fail(%q) = %q, print %q", test.message, err, out.String())
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	answer, stdin, interactive, stdout, stderr := util.Answer, util.Stdin, util.Interactive, Stdout, Stderr
+	defer func() {
+		util.Answer, util.Stdin, util.Interactive, Stdout, Stderr = answer, stdin, interactive, stdout, stderr
+	}()
+	Stdout, Stderr = io.Discard, io.Discard
+
+	tests := []struct {
+		answer      int
+		interactive bool
+		input       string
+		want        bool
+		code        int
+	}{
+		{util.ANSWER_ASK, true, "Y\n", true, util.EXIT_OK},
+		{util.ANSWER_ASK, true, "\n", false, util.EXIT_OK},
+		{util.ANSWER_ASK, false, "y\n", false, util.EXIT_USAGE},
+		{util.ANSWER_ASK, true, "", false, util.EXIT_USAGE},
+		{util.ANSWER_YES, false, "", true, util.EXIT_OK},
+		{util.ANSWER_NO, true, "y\n", false, util.EXIT_OK},
+	}
+	for _, test := range tests {
+		util.Answer, util.Interactive, util.Stdin = test.answer, test.interactive, strings.NewReader(test.input)
+		if ok, err := util.Confirm("download %v version [Y/n]? ", "3.8.1"); ok != test.want || util.ExitCode(err) != test.code {
+			t.Errorf("Confirm() answer %v, interactive %v, input %q = %v, %v", test.answer, test.interactive, test.input, ok, err)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		s    string
+		want time.Time
+		ok   bool
+	}{
+		{"180d", now.AddDate(0, 0, -180), true},
+		{"2W", now.AddDate(0, 0, -14), true},
+		{"36h", now.Add(-36 * time.Hour), true},
+		{"2024-01-01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), true},
+		{"-1d", time.Time{}, false},
+		{"abc", time.Time{}, false},
+	}
+	for _, test := range tests {
+		got, err := util.ParseSince(test.s, now)
+		if (err == nil) != test.ok || !got.Equal(test.want) {
+			t.Errorf("ParseSince(%v) = %v, %v, want %v", test.s, got, err, test.want)
+		}
+	}
+	for n, want := range map[int64]string{512: "512 B", 1536: "1.5 KB", 30 << 20: "30.0 MB", 3 << 30: "3.0 GB"} {
+		if got := util.FormatSize(n); got != want {
+			t.Errorf("FormatSize(%v) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestCompareNodeVer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		line string
+	}{
+		{"18.16.0", "v18.16.0", 0, "v18"},
+		{"18.9.0", "18.16.0", -1, "v18"},
+		{"18.16.100", "18.16.99", 1, "v18"},
+		{"0.12.18", "0.10.48", 1, "v0.12"},
+		{"20.1.0-x86", "20.0.9", 1, "v20"},
+	}
+	for _, test := range tests {
+		if got := util.CompareNodeVer(test.a, test.b); got != test.want {
+			t.Errorf("CompareNodeVer(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+		if got := util.NodeLine(test.a); got != test.line {
+			t.Errorf("NodeLine(%v) = %v, want %v", test.a, got, test.line)
+		}
+	}
+}
+
+func TestVerifyRoot(t *testing.T) {
+	path, source := util.GlobalNodePath, util.RootSource
+	defer func() { util.GlobalNodePath, util.RootSource = path, source }()
+
+	util.GlobalNodePath, util.RootSource = filepath.Join(t.TempDir(), "nonexist"), util.ROOT_ENV
+	if err := util.VerifyRoot(); err == nil {
+		t.Fatalf("VerifyRoot(%v) err is nil", util.GlobalNodePath)
+	}
+	util.GlobalNodePath = t.TempDir()
+	if err := util.VerifyRoot(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	// go
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// local
+	. "gnvm/console"
+)
+
+/*
+Node.js version parse errors, usage errors.Is, include:
+  - ErrUnsupportedVersion: version not node.exe download, e.g. 0.1.0
+  - ErrArchSuffix:         suffix not x86 or x64, e.g. 5.10.1-x32
+  - ErrVersionFormat:      too many suffix, e.g. 5.10.1-x86-x64
+  - ErrInvalidVersion:     not semver, e.g. 5.10 abc
+  - ErrNPMVersion:         npm keyword, e.g. gnvm install npm
+*/
+var (
+	ErrUnsupportedVersion = errors.New("not node.exe download")
+	ErrArchSuffix         = errors.New("format error, suffix only must be 'x86' or 'x64'")
+	ErrVersionFormat      = errors.New("format error, parameter must be 'x.xx.xx' or 'x.xx.xx-x86|x64'")
+	ErrInvalidVersion     = errors.New("not an valid Node.js version")
+	ErrNPMVersion         = errors.New("not an Node.js version, npm usage 'gnvm npm'")
+)
+
+/*
+Node.js version parse error, usage errors.As, exit code is EXIT_USAGE
+
+Field:
+  - Input: parse string, e.g. 5.10.1-x32
+  - Err:   reason, e.g. ErrArchSuffix
+*/
+type VersionError struct {
+	Input string
+	Err   error
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%v %v", e.Input, T(e.Err.Error()))
+}
+
+func (e *VersionError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Compare Node.js versions by major, minor and patch, pre-release tag is ignored
+
+Param:
+  - a, b: x.xx.xx or vx.xx.xx
+
+Return:
+  - -1 when a < b, 0 when a == b, 1 when a > b
+*/
+func CompareNodeVer(a, b string) int {
+	x, y := semver(a), semver(b)
+	for i := range x {
+		switch {
+		case x[i] < y[i]:
+			return -1
+		case x[i] > y[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+/*
+Return release line of Node.js version, 0.x line is minor, others are major
+
+Param:
+  - version: x.xx.xx or vx.xx.xx
+
+Return:
+  - line: e.g. v18 v0.12, the same as schedule.json key
+*/
+func NodeLine(version string) string {
+	v := semver(version)
+	if v[0] == 0 {
+		return fmt.Sprintf("v0.%v", v[1])
+	}
+	return fmt.Sprintf("v%v", v[0])
+}
+
+func semver(version string) [3]int {
+	var v [3]int
+	version = strings.Split(strings.TrimPrefix(version, "v"), "-")[0]
+	for i, s := range strings.SplitN(version, ".", 3) {
+		v[i], _ = strconv.Atoi(s)
+	}
+	return v
+}